@@ -0,0 +1,197 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidTransition is returned when acknowledging or resolving an alert that is already resolved
+var ErrInvalidTransition = errors.New("invalid alert state transition")
+
+// Filter selects alerts to list; empty fields match everything
+type Filter struct {
+	Status      string
+	Severity    string
+	PersonaName string
+}
+
+// Service checks benchmarks against the alert rules and manages the resulting alerts
+type Service struct {
+	rules Rules
+	bus   *events.Bus
+	mu    sync.Mutex // Serialises alert updates so duplicates are not raised concurrently
+}
+
+// NewService creates an alerting service that checks every completed benchmark published on bus
+func NewService(rules Rules, bus *events.Bus) *Service {
+	s := &Service{rules: rules, bus: bus}
+	if bus != nil {
+		bus.Subscribe(events.BenchmarkCompleted, s.handleBenchmarkCompleted)
+	}
+	return s
+}
+
+// handleBenchmarkCompleted checks a completed benchmark against the rules
+func (s *Service) handleBenchmarkCompleted(event events.Event) {
+	results, ok := event.Data.(models.BenchmarkResults)
+	if !ok {
+		log.Printf("Unexpected %s event data: %T", event.Type, event.Data)
+		return
+	}
+	if _, err := s.CheckBenchmark(results); err != nil {
+		log.Printf("Error checking benchmark %s for regressions: %v", results.BenchmarkID, err)
+	}
+}
+
+// CheckBenchmark runs the rules against a benchmark and raises an alert for each breach
+func (s *Service) CheckBenchmark(results models.BenchmarkResults) ([]models.Alert, error) {
+	history, err := previousBenchmarks(results)
+	if err != nil {
+		return nil, err
+	}
+
+	var raised []models.Alert
+	for _, alert := range check(s.rules, results, history) {
+		stored, created, err := s.Raise(alert)
+		if err != nil {
+			return raised, err
+		}
+		if created {
+			raised = append(raised, *stored)
+		}
+	}
+	return raised, nil
+}
+
+// previousBenchmarks returns the persona's successful benchmarks from before results. Benchmarks
+// without scores are left out, so they do not drag down the trailing average.
+func previousBenchmarks(results models.BenchmarkResults) ([]models.BenchmarkResults, error) {
+	all, err := storage.ListBenchmarkResults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list benchmark results: %w", err)
+	}
+
+	var history []models.BenchmarkResults
+	for _, previous := range all {
+		if previous.BenchmarkID == results.BenchmarkID || previous.PersonaName != results.PersonaName ||
+			previous.FailureReason != "" || !hasScores(previous) || !previous.Timestamp.Before(results.Timestamp) {
+			continue
+		}
+		history = append(history, previous)
+	}
+	return history, nil
+}
+
+// Raise stores a new open alert and publishes it, unless the same rule already has an unresolved
+// alert for the persona. It reports whether a new alert was created.
+func (s *Service) Raise(alert models.Alert) (*models.Alert, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := storage.ListAlerts()
+	if err != nil {
+		return nil, false, err
+	}
+	for _, open := range existing {
+		if open.Status != models.AlertStatusResolved && open.Rule == alert.Rule && open.PersonaName == alert.PersonaName {
+			log.Printf("Alert %s for persona %s is still unresolved (ID: %s), not raising another", alert.Rule, alert.PersonaName, open.ID)
+			return &open, false, nil
+		}
+	}
+
+	alert.ID = uuid.NewString()
+	alert.Status = models.AlertStatusOpen
+	alert.CreatedAt = time.Now()
+	if err := storage.SaveAlert(alert); err != nil {
+		return nil, false, err
+	}
+
+	log.Printf("Raised %s alert %s for persona %s: %s", alert.Severity, alert.Rule, alert.PersonaName, alert.Message)
+	s.bus.Publish(events.AlertRaised, alert)
+	return &alert, true, nil
+}
+
+// List returns the alerts matching filter, newest first
+func (s *Service) List(filter Filter) ([]models.Alert, error) {
+	all, err := storage.ListAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]models.Alert, 0, len(all))
+	for _, alert := range all {
+		if (filter.Status == "" || alert.Status == filter.Status) &&
+			(filter.Severity == "" || alert.Severity == filter.Severity) &&
+			(filter.PersonaName == "" || alert.PersonaName == filter.PersonaName) {
+			alerts = append(alerts, alert)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].CreatedAt.After(alerts[j].CreatedAt)
+	})
+	return alerts, nil
+}
+
+// Get returns an alert by ID
+func (s *Service) Get(alertID string) (*models.Alert, error) {
+	return storage.GetAlert(alertID)
+}
+
+// Acknowledge marks an open alert as seen
+func (s *Service) Acknowledge(alertID string, request models.AlertActionRequest) (*models.Alert, error) {
+	return s.update(alertID, func(alert *models.Alert, now time.Time) error {
+		if alert.Status != models.AlertStatusOpen {
+			return fmt.Errorf("%w: alert is %s", ErrInvalidTransition, alert.Status)
+		}
+		alert.Status = models.AlertStatusAcknowledged
+		alert.AcknowledgedAt = &now
+		alert.AcknowledgedBy = request.By
+		if request.Note != "" {
+			alert.Note = request.Note
+		}
+		return nil
+	})
+}
+
+// Resolve closes an open or acknowledged alert, allowing the rule to raise a new one
+func (s *Service) Resolve(alertID string, request models.AlertActionRequest) (*models.Alert, error) {
+	return s.update(alertID, func(alert *models.Alert, now time.Time) error {
+		if alert.Status == models.AlertStatusResolved {
+			return fmt.Errorf("%w: alert is already resolved", ErrInvalidTransition)
+		}
+		alert.Status = models.AlertStatusResolved
+		alert.ResolvedAt = &now
+		alert.ResolvedBy = request.By
+		if request.Note != "" {
+			alert.Note = request.Note
+		}
+		return nil
+	})
+}
+
+// update applies change to a stored alert and saves it
+func (s *Service) update(alertID string, change func(alert *models.Alert, now time.Time) error) (*models.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alert, err := storage.GetAlert(alertID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(alert, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := storage.SaveAlert(*alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
@@ -0,0 +1,40 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+func TestPreviousBenchmarks(t *testing.T) {
+	if err := storage.InitDB(t.TempDir(), 0); err != nil {
+		t.Fatalf("InitDB() error = %v", err)
+	}
+	t.Cleanup(storage.CloseDB)
+
+	now := time.Now()
+	current := models.BenchmarkResults{BenchmarkID: "current", PersonaName: "alpha", Coverage: 100, Timestamp: now}
+	saved := []models.BenchmarkResults{
+		current,
+		{BenchmarkID: "earlier", PersonaName: "alpha", QualityScore: 80, Coverage: 100, Timestamp: now.Add(-time.Hour)},
+		{BenchmarkID: "outage", PersonaName: "alpha", Coverage: 0, Timestamp: now.Add(-time.Hour)},
+		{BenchmarkID: "failed", PersonaName: "alpha", FailureReason: "boom", Timestamp: now.Add(-time.Hour)},
+		{BenchmarkID: "other", PersonaName: "beta", Coverage: 100, Timestamp: now.Add(-time.Hour)},
+		{BenchmarkID: "later", PersonaName: "alpha", Coverage: 100, Timestamp: now.Add(time.Hour)},
+	}
+	for _, results := range saved {
+		if err := storage.SaveBenchmarkResults(results.BenchmarkID, results); err != nil {
+			t.Fatalf("SaveBenchmarkResults(%s) error = %v", results.BenchmarkID, err)
+		}
+	}
+
+	history, err := previousBenchmarks(current)
+	if err != nil {
+		t.Fatalf("previousBenchmarks() error = %v", err)
+	}
+	if len(history) != 1 || history[0].BenchmarkID != "earlier" {
+		t.Errorf("history = %+v, want only the earlier scored benchmark", history)
+	}
+}
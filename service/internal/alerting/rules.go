@@ -0,0 +1,138 @@
+package alerting
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// Rule names recorded on alerts
+const (
+	RuleQualityBelowThreshold   = "quality_below_threshold"
+	RuleQualityDrop             = "quality_drop"
+	RuleRelevanceBelowThreshold = "relevance_below_threshold"
+	RuleMissingItems            = "missing_items"
+)
+
+// Rules are the regression checks run after every benchmark. A zero limit disables its rule.
+type Rules struct {
+	MinQualityScore      float64
+	MaxQualityDrop       float64 // Points below the trailing average of previous benchmarks
+	TrailingWindow       int     // Number of previous benchmarks in the trailing average
+	MinRelevanceAccuracy float64 // Percentage, compared against the relevance accuracy fraction scaled by 100
+	MaxMissingItems      int     // Items missing from the output or without a source
+}
+
+// RulesFromSpec reads the alert rules from the configuration
+func RulesFromSpec(spec *internal.Specification) Rules {
+	return Rules{
+		MinQualityScore:      spec.AlertMinQualityScore,
+		MaxQualityDrop:       spec.AlertMaxQualityDrop,
+		TrailingWindow:       spec.AlertTrailingWindow,
+		MinRelevanceAccuracy: spec.AlertMinRelevanceAccuracy,
+		MaxMissingItems:      spec.AlertMaxMissingItems,
+	}
+}
+
+// check returns the alerts a benchmark breaches. history holds the persona's earlier
+// successful benchmarks and is used for the trailing average.
+func check(rules Rules, results models.BenchmarkResults, history []models.BenchmarkResults) []models.Alert {
+	newAlert := func(rule, severity string, value, threshold float64, message string) models.Alert {
+		return models.Alert{
+			Rule:        rule,
+			Severity:    severity,
+			PersonaName: results.PersonaName,
+			BenchmarkID: results.BenchmarkID,
+			RunID:       results.RunID,
+			Message:     message,
+			Value:       value,
+			Threshold:   threshold,
+		}
+	}
+
+	var alerts []models.Alert
+
+	// A benchmark in which no item could be judged, as during a judge outage, has no scores to check
+	scored := hasScores(results)
+
+	if scored && rules.MinQualityScore > 0 && results.QualityScore < rules.MinQualityScore {
+		alerts = append(alerts, newAlert(RuleQualityBelowThreshold, models.AlertSeverityCritical,
+			results.QualityScore, rules.MinQualityScore,
+			fmt.Sprintf("Quality score %.1f for %s is below the minimum of %.1f", results.QualityScore, results.PersonaName, rules.MinQualityScore)))
+	}
+
+	if scored && rules.MaxQualityDrop > 0 {
+		if average, n := trailingAverage(history, rules.TrailingWindow); n > 0 {
+			drop := average - results.QualityScore
+			if drop > rules.MaxQualityDrop {
+				// A drop of twice the allowed margin is treated as critical
+				severity := models.AlertSeverityWarning
+				if drop >= 2*rules.MaxQualityDrop {
+					severity = models.AlertSeverityCritical
+				}
+				alerts = append(alerts, newAlert(RuleQualityDrop, severity, drop, rules.MaxQualityDrop,
+					fmt.Sprintf("Quality score %.1f for %s is %.1f points below the average of %.1f over the previous %d benchmark(s)",
+						results.QualityScore, results.PersonaName, drop, average, n)))
+			}
+		}
+	}
+
+	// Relevance accuracy is stored as a fraction, but the limit is a percentage like the quality score
+	if accuracy := results.RelevanceAccuracy * 100; scored && rules.MinRelevanceAccuracy > 0 && accuracy < rules.MinRelevanceAccuracy {
+		alerts = append(alerts, newAlert(RuleRelevanceBelowThreshold, models.AlertSeverityCritical,
+			accuracy, rules.MinRelevanceAccuracy,
+			fmt.Sprintf("Relevance accuracy %.1f%% for %s is below the minimum of %.1f%%", accuracy, results.PersonaName, rules.MinRelevanceAccuracy)))
+	}
+
+	if rules.MaxMissingItems > 0 {
+		if missing := missingItems(results); missing > rules.MaxMissingItems {
+			severity := models.AlertSeverityWarning
+			if missing >= 2*rules.MaxMissingItems {
+				severity = models.AlertSeverityCritical
+			}
+			alerts = append(alerts, newAlert(RuleMissingItems, severity, float64(missing), float64(rules.MaxMissingItems),
+				fmt.Sprintf("%d item(s) of run %s for %s are missing from the output or have no source, above the limit of %d",
+					missing, results.RunID, results.PersonaName, rules.MaxMissingItems)))
+		}
+	}
+
+	return alerts
+}
+
+// hasScores reports whether any item of a benchmark was judged, so its scores mean something
+func hasScores(results models.BenchmarkResults) bool {
+	return results.Coverage > 0
+}
+
+// trailingAverage averages the quality score of the most recent window benchmarks in history
+func trailingAverage(history []models.BenchmarkResults, window int) (float64, int) {
+	recent := append([]models.BenchmarkResults(nil), history...)
+	sort.Slice(recent, func(i, j int) bool {
+		return recent[i].Timestamp.After(recent[j].Timestamp)
+	})
+	if window > 0 && len(recent) > window {
+		recent = recent[:window]
+	}
+	if len(recent) == 0 {
+		return 0, 0
+	}
+
+	var total float64
+	for _, results := range recent {
+		total += results.QualityScore
+	}
+	return total / float64(len(recent)), len(recent)
+}
+
+// missingItems counts items dropped from the processed output or without an identifiable source
+func missingItems(results models.BenchmarkResults) int {
+	missing := 0
+	for _, eval := range results.DetailedEvaluations {
+		if eval.Status == models.ItemStatusMissingFromOutput || eval.Status == models.ItemStatusNoSource {
+			missing++
+		}
+	}
+	return missing
+}
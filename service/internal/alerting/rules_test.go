@@ -0,0 +1,115 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestCheck(t *testing.T) {
+	now := time.Now()
+	history := []models.BenchmarkResults{
+		{QualityScore: 80, Coverage: 100, Timestamp: now.Add(-3 * time.Hour)},
+		{QualityScore: 90, Coverage: 100, Timestamp: now.Add(-2 * time.Hour)},
+		{QualityScore: 70, Coverage: 100, Timestamp: now.Add(-1 * time.Hour)},
+	}
+	missing := map[string]models.EvaluationResult{
+		"a": {Status: models.ItemStatusMissingFromOutput},
+		"b": {Status: models.ItemStatusNoSource},
+		"c": {Status: models.ItemStatusEvaluated},
+	}
+
+	tests := []struct {
+		name       string
+		rules      Rules
+		results    models.BenchmarkResults
+		history    []models.BenchmarkResults
+		want       map[string]string // Rule to severity
+		wantValues map[string]float64
+	}{
+		{
+			name:    "all rules disabled",
+			rules:   Rules{},
+			results: models.BenchmarkResults{QualityScore: 10, Coverage: 100, RelevanceAccuracy: 0.1, DetailedEvaluations: missing},
+			history: history,
+			want:    map[string]string{},
+		},
+		{
+			name:    "healthy benchmark",
+			rules:   Rules{MinQualityScore: 50, MaxQualityDrop: 10, TrailingWindow: 3, MinRelevanceAccuracy: 80, MaxMissingItems: 5},
+			results: models.BenchmarkResults{QualityScore: 78, Coverage: 100, RelevanceAccuracy: 0.9, DetailedEvaluations: missing},
+			history: history,
+			want:    map[string]string{},
+		},
+		{
+			name:    "below thresholds",
+			rules:   Rules{MinQualityScore: 50, MinRelevanceAccuracy: 80},
+			results: models.BenchmarkResults{QualityScore: 40, Coverage: 100, RelevanceAccuracy: 0.75},
+			want: map[string]string{
+				RuleQualityBelowThreshold:   models.AlertSeverityCritical,
+				RuleRelevanceBelowThreshold: models.AlertSeverityCritical,
+			},
+			wantValues: map[string]float64{RuleRelevanceBelowThreshold: 75}, // Reported as a percentage
+		},
+		{
+			name:       "drop against trailing window",
+			rules:      Rules{MaxQualityDrop: 10, TrailingWindow: 2},
+			results:    models.BenchmarkResults{QualityScore: 65, Coverage: 100},
+			history:    history,
+			want:       map[string]string{RuleQualityDrop: models.AlertSeverityWarning},
+			wantValues: map[string]float64{RuleQualityDrop: 15}, // Average of the two most recent is 80
+		},
+		{
+			name:    "large drop is critical",
+			rules:   Rules{MaxQualityDrop: 10, TrailingWindow: 3},
+			results: models.BenchmarkResults{QualityScore: 55, Coverage: 100},
+			history: history,
+			want:    map[string]string{RuleQualityDrop: models.AlertSeverityCritical},
+		},
+		{
+			name:    "no history means no drop",
+			rules:   Rules{MaxQualityDrop: 10, TrailingWindow: 3},
+			results: models.BenchmarkResults{QualityScore: 10, Coverage: 100},
+			want:    map[string]string{},
+		},
+		{
+			name:  "nothing judged",
+			rules: Rules{MinQualityScore: 50, MaxQualityDrop: 10, TrailingWindow: 3, MinRelevanceAccuracy: 80, MaxMissingItems: 1},
+			results: models.BenchmarkResults{DetailedEvaluations: map[string]models.EvaluationResult{
+				"a": {Status: models.ItemStatusJudgeError},
+				"b": {Status: models.ItemStatusSkipped},
+			}},
+			history: history,
+			want:    map[string]string{},
+		},
+		{
+			name:       "missing items",
+			rules:      Rules{MaxMissingItems: 1},
+			results:    models.BenchmarkResults{DetailedEvaluations: missing},
+			want:       map[string]string{RuleMissingItems: models.AlertSeverityCritical},
+			wantValues: map[string]float64{RuleMissingItems: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := check(tt.rules, tt.results, tt.history)
+			got := make(map[string]string)
+			for _, alert := range alerts {
+				got[alert.Rule] = alert.Severity
+				if want, ok := tt.wantValues[alert.Rule]; ok && alert.Value != want {
+					t.Errorf("%s value = %.1f, want %.1f", alert.Rule, alert.Value, want)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("alerts = %v, want %v", got, tt.want)
+			}
+			for rule, severity := range tt.want {
+				if got[rule] != severity {
+					t.Errorf("%s severity = %q, want %q", rule, got[rule], severity)
+				}
+			}
+		})
+	}
+}
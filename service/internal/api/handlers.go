@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,8 +10,14 @@ import (
 	"time"
 
 	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/alerting"
 	"github.com/bakkerme/ai-news-auditability-service/internal/benchmark"
+	"github.com/bakkerme/ai-news-auditability-service/internal/digest"
+	"github.com/bakkerme/ai-news-auditability-service/internal/drift"
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
 	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/notify"
+	"github.com/bakkerme/ai-news-auditability-service/internal/scheduler"
 	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
 
 	"github.com/google/uuid"
@@ -19,22 +26,38 @@ import (
 
 // API holds dependencies for API handlers.
 type API struct {
-	spec            *internal.Specification
+	spec             *internal.Specification
 	benchmarkService *benchmark.BenchmarkService
+	scheduler        *scheduler.Scheduler
+	alerts           *alerting.Service
+	webhooks         *notify.Service
+	digests          *digest.Service
+	drift            *drift.Service
+	events           *events.Bus
+}
+
+// Services are the long-lived services the API handlers use.
+type Services struct {
+	Benchmarks *benchmark.BenchmarkService
+	Scheduler  *scheduler.Scheduler
+	Alerts     *alerting.Service
+	Webhooks   *notify.Service
+	Digests    *digest.Service
+	Drift      *drift.Service
+	Events     *events.Bus
 }
 
 // NewAPI creates a new API handler instance.
-func NewAPI(s *internal.Specification) *API {
-	// Initialize benchmark service with LLM configuration from spec
-	benchmarkService := benchmark.NewBenchmarkService(
-		s.LlmURL,
-		s.LlmAPIKey,
-		s.LlmModel,
-	)
-	
+func NewAPI(s *internal.Specification, services Services) *API {
 	return &API{
-		spec:            s,
-		benchmarkService: benchmarkService,
+		spec:             s,
+		benchmarkService: services.Benchmarks,
+		scheduler:        services.Scheduler,
+		alerts:           services.Alerts,
+		webhooks:         services.Webhooks,
+		digests:          services.Digests,
+		drift:            services.Drift,
+		events:           services.Events,
 	}
 }
 
@@ -72,6 +95,25 @@ func (h *API) SubmitRun(c echo.Context) error {
 		Message: "Run data successfully received and stored",
 		Status:  "stored",
 	}
+
+	// The run is already stored, so a failure to queue its benchmark does not fail the submission
+	benchmarkResponse, err := h.benchmarkService.AutoBenchmark(runData)
+	if err != nil {
+		log.Printf("Error auto-benchmarking run %s: %v", submissionID, err)
+		response.Message = "Run data stored, but the automatic benchmark could not be queued: " + err.Error()
+	} else if benchmarkResponse != nil {
+		response.Status = "benchmark_queued"
+		response.Message = "Run data stored and benchmark queued for processing"
+		response.BenchmarkID = benchmarkResponse.ID
+	}
+
+	h.events.Publish(events.RunIngested, models.RunMetadata{
+		ID:           submissionID,
+		PersonaName:  runData.Persona.Name,
+		RunDate:      runData.RunDate,
+		TotalItems:   len(runData.EntrySummaries),
+		HasBenchmark: response.BenchmarkID != "",
+	})
 	return c.JSON(http.StatusCreated, response)
 }
 
@@ -145,10 +187,19 @@ func (h *API) GetLatestRun(c echo.Context) error {
 // CreateBenchmark handles POST /benchmarks/create/{runId}
 func (h *API) CreateBenchmark(c echo.Context) error {
 	runID := c.Param("runId")
-	
+
+	// The request body is optional; an empty body uses the defaults
+	var request models.CreateBenchmarkRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid benchmark request: " + err.Error()})
+	}
+
 	// Create benchmark using the benchmark service
-	response, err := h.benchmarkService.CreateBenchmark(runID)
+	response, err := h.benchmarkService.CreateBenchmark(runID, request)
 	if err != nil {
+		if errors.Is(err, benchmark.ErrInvalidSampling) {
+			return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		}
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("Run with ID '%s' not found", runID)})
 		}
@@ -176,6 +227,132 @@ func (h *API) GetBenchmark(c echo.Context) error {
 	return c.JSON(http.StatusOK, results)
 }
 
+// ReevaluateBenchmarkItem handles POST /benchmarks/{benchmarkId}/items/{itemId}/reevaluate
+func (h *API) ReevaluateBenchmarkItem(c echo.Context) error {
+	benchmarkID := c.Param("benchmarkId")
+	itemID := c.Param("itemId")
+
+	eval, err := h.benchmarkService.ReevaluateItem(benchmarkID, itemID)
+	if err != nil {
+		return reevaluationError(c, err, fmt.Sprintf("Failed to re-evaluate item '%s' of benchmark '%s'", itemID, benchmarkID))
+	}
+
+	return c.JSON(http.StatusOK, eval)
+}
+
+// RetryFailedBenchmarkItems handles POST /benchmarks/{benchmarkId}/retry-failed
+func (h *API) RetryFailedBenchmarkItems(c echo.Context) error {
+	benchmarkID := c.Param("benchmarkId")
+
+	response, err := h.benchmarkService.RetryFailedItems(benchmarkID)
+	if err != nil {
+		return reevaluationError(c, err, fmt.Sprintf("Failed to retry failed items of benchmark '%s'", benchmarkID))
+	}
+
+	if response.Status == "queued" {
+		return c.JSON(http.StatusAccepted, response)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// reevaluationError maps re-evaluation errors to HTTP responses
+func reevaluationError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, benchmark.ErrRubricChanged), errors.Is(err, benchmark.ErrNotReevaluable), errors.Is(err, benchmark.ErrJudgeUnavailable):
+		return c.JSON(http.StatusConflict, models.Error{Code: http.StatusConflict, Message: err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+	}
+	log.Printf("%s: %v", message, err)
+	return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: message + ": " + err.Error()})
+}
+
+// CreatePairwiseComparison handles POST /benchmarks/pairwise?base={runId}&head={runId}
+func (h *API) CreatePairwiseComparison(c echo.Context) error {
+	baseRunID := c.QueryParam("base")
+	headRunID := c.QueryParam("head")
+	if baseRunID == "" || headRunID == "" {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Both base and head run IDs are required"})
+	}
+	if baseRunID == headRunID {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Base and head must be different runs"})
+	}
+
+	response, err := h.benchmarkService.CreatePairwiseComparison(baseRunID, headRunID)
+	if err != nil {
+		if errors.Is(err, benchmark.ErrPersonaMismatch) {
+			return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+		}
+		log.Printf("Error creating pairwise comparison for runs %s and %s: %v", baseRunID, headRunID, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to create pairwise comparison: " + err.Error()})
+	}
+
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// GetPairwiseComparison handles GET /benchmarks/pairwise/{comparisonId}
+func (h *API) GetPairwiseComparison(c echo.Context) error {
+	comparisonID := c.Param("comparisonId")
+
+	results, err := h.benchmarkService.GetPairwiseResults(comparisonID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("Pairwise comparison '%s' not found", comparisonID)})
+		}
+		log.Printf("Error getting pairwise comparison %s: %v", comparisonID, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to retrieve pairwise comparison: " + err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// CompareBenchmarks handles GET /benchmarks/compare?base={benchmarkId}&head={benchmarkId}
+func (h *API) CompareBenchmarks(c echo.Context) error {
+	baseID := c.QueryParam("base")
+	headID := c.QueryParam("head")
+	if baseID == "" || headID == "" {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Both base and head benchmark IDs are required"})
+	}
+
+	comparison, err := h.benchmarkService.CompareBenchmarks(baseID, headID)
+	if err != nil {
+		return comparisonError(c, baseID, headID, err)
+	}
+
+	return c.JSON(http.StatusOK, comparison)
+}
+
+// CompareBenchmarkSignificance handles GET /benchmarks/compare/significance?base={benchmarkId}&head={benchmarkId}
+func (h *API) CompareBenchmarkSignificance(c echo.Context) error {
+	baseID := c.QueryParam("base")
+	headID := c.QueryParam("head")
+	if baseID == "" || headID == "" {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Both base and head benchmark IDs are required"})
+	}
+
+	significance, err := h.benchmarkService.CompareSignificance(baseID, headID)
+	if err != nil {
+		return comparisonError(c, baseID, headID, err)
+	}
+
+	return c.JSON(http.StatusOK, significance)
+}
+
+// comparisonError maps a benchmark comparison error to a response
+func comparisonError(c echo.Context, baseID, headID string, err error) error {
+	if errors.Is(err, benchmark.ErrNotComparable) {
+		return c.JSON(http.StatusConflict, models.Error{Code: http.StatusConflict, Message: err.Error()})
+	}
+	if strings.Contains(err.Error(), "not found") {
+		return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+	}
+	log.Printf("Error comparing benchmarks %s and %s: %v", baseID, headID, err)
+	return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to compare benchmarks: " + err.Error()})
+}
+
 // GetBenchmarkLogs handles GET /benchmarks/{runId}/logs
 func (h *API) GetBenchmarkLogs(c echo.Context) error {
 	runID := c.Param("runId")
@@ -207,20 +384,36 @@ func (h *API) StreamBenchmarkLogs(c echo.Context) error {
 
 // GetPersonaMetrics handles GET /metrics/persona/{personaName}
 func (h *API) GetPersonaMetrics(c echo.Context) error {
-	// personaName := c.Param("personaName")
-	// TODO: Implement metrics retrieval logic for the persona
-	// from := c.QueryParam("from")
-	// to := c.QueryParam("to")
+	personaName := c.Param("personaName")
 
-	// Dummy response for now, using the placeholder PersonaMetrics model
-	dummyPersonaMetrics := models.PersonaMetrics{
-		Data: map[string]interface{}{
-			"averageScore": 0.88,
-			"runsAnalyzed": 150,
-			"errorRate":    0.02,
-		},
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: err.Error()})
+	}
+
+	metrics, err := h.benchmarkService.PersonaMetrics(personaName, from, to)
+	if err != nil {
+		log.Printf("Error getting metrics for persona %s: %v", personaName, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to retrieve persona metrics: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, metrics)
+}
+
+// parseTimeRange reads the optional from and to query parameters (ISO 8601)
+func parseTimeRange(c echo.Context) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if v := c.QueryParam("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, fmt.Errorf("invalid from timestamp: %w", err)
+		}
+	}
+	if v := c.QueryParam("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, fmt.Errorf("invalid to timestamp: %w", err)
+		}
 	}
-	return c.JSON(http.StatusOK, dummyPersonaMetrics)
+	return from, to, nil
 }
 
 // GetQualityMetrics handles GET /metrics/quality
@@ -239,3 +432,445 @@ func (h *API) GetQualityMetrics(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, dummyQualityMetrics)
 }
+
+// ListSchedules handles GET /schedules
+func (h *API) ListSchedules(c echo.Context) error {
+	schedules, err := h.scheduler.List()
+	if err != nil {
+		log.Printf("Error listing schedules: %v", err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to retrieve schedules: " + err.Error()})
+	}
+	if schedules == nil {
+		schedules = []models.BenchmarkSchedule{}
+	}
+	return c.JSON(http.StatusOK, schedules)
+}
+
+// GetSchedule handles GET /schedules/{scheduleId}
+func (h *API) GetSchedule(c echo.Context) error {
+	scheduleID := c.Param("scheduleId")
+
+	schedule, err := h.scheduler.Get(scheduleID)
+	if err != nil {
+		return scheduleError(c, err, "Failed to retrieve schedule")
+	}
+	return c.JSON(http.StatusOK, schedule)
+}
+
+// CreateSchedule handles POST /schedules
+func (h *API) CreateSchedule(c echo.Context) error {
+	var request models.ScheduleRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid schedule format: " + err.Error()})
+	}
+
+	schedule, err := h.scheduler.Create(request)
+	if err != nil {
+		return scheduleError(c, err, "Failed to create schedule")
+	}
+	return c.JSON(http.StatusCreated, schedule)
+}
+
+// UpdateSchedule handles PUT /schedules/{scheduleId}
+func (h *API) UpdateSchedule(c echo.Context) error {
+	scheduleID := c.Param("scheduleId")
+
+	var request models.ScheduleRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid schedule format: " + err.Error()})
+	}
+
+	schedule, err := h.scheduler.Update(scheduleID, request)
+	if err != nil {
+		return scheduleError(c, err, "Failed to update schedule")
+	}
+	return c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteSchedule handles DELETE /schedules/{scheduleId}
+func (h *API) DeleteSchedule(c echo.Context) error {
+	scheduleID := c.Param("scheduleId")
+
+	if err := h.scheduler.Delete(scheduleID); err != nil {
+		return scheduleError(c, err, "Failed to delete schedule")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// scheduleError maps schedule errors to HTTP responses
+func scheduleError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, scheduler.ErrInvalidSchedule):
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: message + ": " + err.Error()})
+	}
+}
+
+// ListAlerts handles GET /alerts?status={status}&severity={severity}&persona={personaName}
+func (h *API) ListAlerts(c echo.Context) error {
+	filter := alerting.Filter{
+		Status:      c.QueryParam("status"),
+		Severity:    c.QueryParam("severity"),
+		PersonaName: c.QueryParam("persona"),
+	}
+
+	alerts, err := h.alerts.List(filter)
+	if err != nil {
+		log.Printf("Error listing alerts: %v", err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to retrieve alerts: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, alerts)
+}
+
+// GetAlert handles GET /alerts/{alertId}
+func (h *API) GetAlert(c echo.Context) error {
+	alertID := c.Param("alertId")
+
+	alert, err := h.alerts.Get(alertID)
+	if err != nil {
+		return alertError(c, err, "Failed to retrieve alert")
+	}
+	return c.JSON(http.StatusOK, alert)
+}
+
+// AcknowledgeAlert handles POST /alerts/{alertId}/acknowledge
+func (h *API) AcknowledgeAlert(c echo.Context) error {
+	alertID := c.Param("alertId")
+
+	var request models.AlertActionRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid request format: " + err.Error()})
+	}
+
+	alert, err := h.alerts.Acknowledge(alertID, request)
+	if err != nil {
+		return alertError(c, err, "Failed to acknowledge alert")
+	}
+	return c.JSON(http.StatusOK, alert)
+}
+
+// ResolveAlert handles POST /alerts/{alertId}/resolve
+func (h *API) ResolveAlert(c echo.Context) error {
+	alertID := c.Param("alertId")
+
+	var request models.AlertActionRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid request format: " + err.Error()})
+	}
+
+	alert, err := h.alerts.Resolve(alertID, request)
+	if err != nil {
+		return alertError(c, err, "Failed to resolve alert")
+	}
+	return c.JSON(http.StatusOK, alert)
+}
+
+// alertError maps alert errors to HTTP responses
+func alertError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, alerting.ErrInvalidTransition):
+		return c.JSON(http.StatusConflict, models.Error{Code: http.StatusConflict, Message: err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: message + ": " + err.Error()})
+	}
+}
+
+// ListWebhooks handles GET /webhooks
+func (h *API) ListWebhooks(c echo.Context) error {
+	webhooks, err := h.webhooks.List()
+	if err != nil {
+		log.Printf("Error listing webhooks: %v", err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to retrieve webhooks: " + err.Error()})
+	}
+	if webhooks == nil {
+		webhooks = []models.Webhook{}
+	}
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook handles GET /webhooks/{webhookId}
+func (h *API) GetWebhook(c echo.Context) error {
+	webhookID := c.Param("webhookId")
+
+	webhook, err := h.webhooks.Get(webhookID)
+	if err != nil {
+		return webhookError(c, err, "Failed to retrieve webhook")
+	}
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// CreateWebhook handles POST /webhooks
+func (h *API) CreateWebhook(c echo.Context) error {
+	var request models.WebhookRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid webhook format: " + err.Error()})
+	}
+
+	webhook, err := h.webhooks.Create(request)
+	if err != nil {
+		return webhookError(c, err, "Failed to create webhook")
+	}
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateWebhook handles PUT /webhooks/{webhookId}
+func (h *API) UpdateWebhook(c echo.Context) error {
+	webhookID := c.Param("webhookId")
+
+	var request models.WebhookRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid webhook format: " + err.Error()})
+	}
+
+	webhook, err := h.webhooks.Update(webhookID, request)
+	if err != nil {
+		return webhookError(c, err, "Failed to update webhook")
+	}
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook handles DELETE /webhooks/{webhookId}
+func (h *API) DeleteWebhook(c echo.Context) error {
+	webhookID := c.Param("webhookId")
+
+	if err := h.webhooks.Delete(webhookID); err != nil {
+		return webhookError(c, err, "Failed to delete webhook")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries handles GET /webhooks/{webhookId}/deliveries
+func (h *API) ListWebhookDeliveries(c echo.Context) error {
+	webhookID := c.Param("webhookId")
+
+	deliveries, err := h.webhooks.Deliveries(webhookID)
+	if err != nil {
+		return webhookError(c, err, "Failed to retrieve webhook deliveries")
+	}
+	if deliveries == nil {
+		deliveries = []models.WebhookDelivery{}
+	}
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// webhookError maps webhook errors to HTTP responses
+func webhookError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, notify.ErrInvalidWebhook):
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: message + ": " + err.Error()})
+	}
+}
+
+// PreviewDigest handles GET /digest/preview?from={RFC3339}&to={RFC3339}&format={json|html|text}
+func (h *API) PreviewDigest(c echo.Context) error {
+	from, to := h.digests.DefaultPeriod(time.Now())
+	if toStr := c.QueryParam("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid 'to' date format. Use RFC3339 format."})
+		}
+		from, to = from.Add(parsed.Sub(to)), parsed
+	}
+	if fromStr := c.QueryParam("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid 'from' date format. Use RFC3339 format."})
+		}
+		from = parsed
+	}
+	if !from.Before(to) {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "'from' must be before 'to'"})
+	}
+
+	rendered, err := h.digests.Preview(from, to)
+	if err != nil {
+		log.Printf("Error rendering digest preview: %v", err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to render digest: " + err.Error()})
+	}
+
+	switch c.QueryParam("format") {
+	case "html":
+		return c.HTML(http.StatusOK, rendered.HTML)
+	case "text":
+		return c.String(http.StatusOK, rendered.Text)
+	case "", "json":
+		return c.JSON(http.StatusOK, rendered)
+	default:
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "format must be one of json, html, text"})
+	}
+}
+
+// GetPersonaDrift handles GET /drift/{personaName}
+func (h *API) GetPersonaDrift(c echo.Context) error {
+	personaName := c.Param("personaName")
+
+	report, err := h.drift.Report(personaName)
+	if err != nil {
+		log.Printf("Error analysing drift for persona %s: %v", personaName, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to analyse drift: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// ListGoldenDatasets handles GET /golden
+func (h *API) ListGoldenDatasets(c echo.Context) error {
+	datasets, err := h.benchmarkService.ListGoldenDatasets()
+	if err != nil {
+		return goldenError(c, err, "Failed to list golden datasets")
+	}
+	return c.JSON(http.StatusOK, datasets)
+}
+
+// GetGoldenDataset handles GET /golden/{datasetId}
+func (h *API) GetGoldenDataset(c echo.Context) error {
+	dataset, err := h.benchmarkService.GetGoldenDataset(c.Param("datasetId"))
+	if err != nil {
+		return goldenError(c, err, "Failed to get golden dataset")
+	}
+	return c.JSON(http.StatusOK, dataset)
+}
+
+// CreateGoldenDataset handles POST /golden
+func (h *API) CreateGoldenDataset(c echo.Context) error {
+	var request models.GoldenDatasetRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid golden dataset format: " + err.Error()})
+	}
+
+	dataset, err := h.benchmarkService.CreateGoldenDataset(request)
+	if err != nil {
+		return goldenError(c, err, "Failed to create golden dataset")
+	}
+	return c.JSON(http.StatusCreated, dataset)
+}
+
+// UpdateGoldenDataset handles PUT /golden/{datasetId}
+func (h *API) UpdateGoldenDataset(c echo.Context) error {
+	datasetID := c.Param("datasetId")
+
+	var request models.GoldenDatasetRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid golden dataset format: " + err.Error()})
+	}
+
+	dataset, err := h.benchmarkService.UpdateGoldenDataset(datasetID, request)
+	if err != nil {
+		return goldenError(c, err, "Failed to update golden dataset")
+	}
+	return c.JSON(http.StatusOK, dataset)
+}
+
+// DeleteGoldenDataset handles DELETE /golden/{datasetId}
+func (h *API) DeleteGoldenDataset(c echo.Context) error {
+	if err := h.benchmarkService.DeleteGoldenDataset(c.Param("datasetId")); err != nil {
+		return goldenError(c, err, "Failed to delete golden dataset")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ScoreGoldenDataset handles GET /golden/{datasetId}/score?runId={runId}
+func (h *API) ScoreGoldenDataset(c echo.Context) error {
+	runID := c.QueryParam("runId")
+	if runID == "" {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "runId is required"})
+	}
+
+	score, err := h.benchmarkService.ScoreGoldenDataset(c.Param("datasetId"), runID)
+	if err != nil {
+		return goldenError(c, err, "Failed to score run against golden dataset")
+	}
+	return c.JSON(http.StatusOK, score)
+}
+
+// goldenError maps golden dataset errors to HTTP responses
+func goldenError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, benchmark.ErrInvalidGoldenDataset):
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: message + ": " + err.Error()})
+	}
+}
+
+// ListAnnotations handles GET /runs/{runId}/annotations?itemId={itemId}
+func (h *API) ListAnnotations(c echo.Context) error {
+	annotations, err := h.benchmarkService.ListAnnotations(c.Param("runId"), c.QueryParam("itemId"))
+	if err != nil {
+		return annotationError(c, err, "Failed to list annotations")
+	}
+	return c.JSON(http.StatusOK, annotations)
+}
+
+// CreateAnnotation handles POST /runs/{runId}/items/{itemId}/annotations
+func (h *API) CreateAnnotation(c echo.Context) error {
+	var request models.AnnotationRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid annotation format: " + err.Error()})
+	}
+
+	annotation, err := h.benchmarkService.CreateAnnotation(c.Param("runId"), c.Param("itemId"), request)
+	if err != nil {
+		return annotationError(c, err, "Failed to create annotation")
+	}
+	return c.JSON(http.StatusCreated, annotation)
+}
+
+// UpdateAnnotation handles PUT /runs/{runId}/annotations/{annotationId}
+func (h *API) UpdateAnnotation(c echo.Context) error {
+	var request models.AnnotationRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: "Invalid annotation format: " + err.Error()})
+	}
+
+	annotation, err := h.benchmarkService.UpdateAnnotation(c.Param("runId"), c.Param("annotationId"), request)
+	if err != nil {
+		return annotationError(c, err, "Failed to update annotation")
+	}
+	return c.JSON(http.StatusOK, annotation)
+}
+
+// DeleteAnnotation handles DELETE /runs/{runId}/annotations/{annotationId}
+func (h *API) DeleteAnnotation(c echo.Context) error {
+	if err := h.benchmarkService.DeleteAnnotation(c.Param("runId"), c.Param("annotationId")); err != nil {
+		return annotationError(c, err, "Failed to delete annotation")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// annotationError maps annotation errors to HTTP responses
+func annotationError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, benchmark.ErrInvalidAnnotation):
+		return c.JSON(http.StatusBadRequest, models.Error{Code: http.StatusBadRequest, Message: err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		return c.JSON(http.StatusNotFound, models.Error{Code: http.StatusNotFound, Message: err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: message + ": " + err.Error()})
+	}
+}
+
+// GetCalibration handles GET /calibration?judge={name}&rubricVersion={version}
+func (h *API) GetCalibration(c echo.Context) error {
+	report, err := h.benchmarkService.Calibration(c.QueryParam("judge"), c.QueryParam("rubricVersion"))
+	if err != nil {
+		log.Printf("Error building calibration report: %v", err)
+		return c.JSON(http.StatusInternalServerError, models.Error{Code: http.StatusInternalServerError, Message: "Failed to build calibration report: " + err.Error()})
+	}
+	return c.JSON(http.StatusOK, report)
+}
@@ -15,6 +15,12 @@ func RegisterRoutes(e *echo.Echo, apiHandler *API) {
 	v1.GET("/runs/latest", apiHandler.GetLatestRun) // Get latest run data
 	v1.GET("/runs/:runId", apiHandler.GetRun)       // Get specific run data
 
+	// Human annotations of run items
+	v1.GET("/runs/:runId/annotations", apiHandler.ListAnnotations)                   // List a run's annotations
+	v1.POST("/runs/:runId/items/:itemId/annotations", apiHandler.CreateAnnotation)   // Annotate an item
+	v1.PUT("/runs/:runId/annotations/:annotationId", apiHandler.UpdateAnnotation)    // Update an annotation
+	v1.DELETE("/runs/:runId/annotations/:annotationId", apiHandler.DeleteAnnotation) // Delete an annotation
+
 	// Benchmark Endpoints
 	v1.POST("/benchmarks/create/:runId", apiHandler.CreateBenchmark) // Create new benchmark
 	v1.GET("/benchmarks/:runId", apiHandler.GetBenchmark)            // Get benchmark results
@@ -22,6 +28,56 @@ func RegisterRoutes(e *echo.Echo, apiHandler *API) {
 	// WebSocket endpoint for streaming logs
 	v1.GET("/benchmarks/:runId/logs/stream", apiHandler.StreamBenchmarkLogs)
 
+	// Re-evaluation of items in an existing benchmark
+	v1.POST("/benchmarks/:benchmarkId/items/:itemId/reevaluate", apiHandler.ReevaluateBenchmarkItem) // Re-judge a single item
+	v1.POST("/benchmarks/:benchmarkId/retry-failed", apiHandler.RetryFailedBenchmarkItems)           // Re-judge all errored items
+
+	// Pairwise comparison of two runs
+	v1.POST("/benchmarks/pairwise", apiHandler.CreatePairwiseComparison)           // Compare two runs pairwise
+	v1.GET("/benchmarks/pairwise/:comparisonId", apiHandler.GetPairwiseComparison) // Get pairwise comparison results
+
+	// Comparison of two benchmarks
+	v1.GET("/benchmarks/compare", apiHandler.CompareBenchmarks)                         // Diff two benchmarks item by item
+	v1.GET("/benchmarks/compare/significance", apiHandler.CompareBenchmarkSignificance) // Test whether score differences are significant
+
+	// Benchmark schedules
+	v1.GET("/schedules", apiHandler.ListSchedules)                 // List schedules
+	v1.POST("/schedules", apiHandler.CreateSchedule)               // Create a schedule
+	v1.GET("/schedules/:scheduleId", apiHandler.GetSchedule)       // Get a schedule
+	v1.PUT("/schedules/:scheduleId", apiHandler.UpdateSchedule)    // Update a schedule
+	v1.DELETE("/schedules/:scheduleId", apiHandler.DeleteSchedule) // Delete a schedule
+
+	// Quality regression alerts
+	v1.GET("/alerts", apiHandler.ListAlerts)                             // List alerts
+	v1.GET("/alerts/:alertId", apiHandler.GetAlert)                      // Get an alert
+	v1.POST("/alerts/:alertId/acknowledge", apiHandler.AcknowledgeAlert) // Acknowledge an alert
+	v1.POST("/alerts/:alertId/resolve", apiHandler.ResolveAlert)         // Resolve an alert
+
+	// Outbound webhooks
+	v1.GET("/webhooks", apiHandler.ListWebhooks)                                // List webhooks
+	v1.POST("/webhooks", apiHandler.CreateWebhook)                              // Create a webhook
+	v1.GET("/webhooks/:webhookId", apiHandler.GetWebhook)                       // Get a webhook
+	v1.PUT("/webhooks/:webhookId", apiHandler.UpdateWebhook)                    // Update a webhook
+	v1.DELETE("/webhooks/:webhookId", apiHandler.DeleteWebhook)                 // Delete a webhook
+	v1.GET("/webhooks/:webhookId/deliveries", apiHandler.ListWebhookDeliveries) // Delivery log of a webhook
+
+	// Email digests
+	v1.GET("/digest/preview", apiHandler.PreviewDigest) // Render the digest without sending it
+
+	// Judge calibration against human annotations
+	v1.GET("/calibration", apiHandler.GetCalibration) // Agreement of each judge and rubric with human annotators
+
+	// Golden reference datasets
+	v1.GET("/golden", apiHandler.ListGoldenDatasets)                  // List golden datasets
+	v1.POST("/golden", apiHandler.CreateGoldenDataset)                // Create a golden dataset
+	v1.GET("/golden/:datasetId", apiHandler.GetGoldenDataset)         // Get a golden dataset
+	v1.PUT("/golden/:datasetId", apiHandler.UpdateGoldenDataset)      // Update a golden dataset
+	v1.DELETE("/golden/:datasetId", apiHandler.DeleteGoldenDataset)   // Delete a golden dataset
+	v1.GET("/golden/:datasetId/score", apiHandler.ScoreGoldenDataset) // Score a run against a golden dataset
+
+	// Drift detection
+	v1.GET("/drift/:personaName", apiHandler.GetPersonaDrift) // Drift of a persona's recent runs against its baseline
+
 	// Metrics Endpoints
 	// Note: The OpenAPI spec shows /metrics/persona/{personaName} and then other /metrics/ endpoints.
 	// I'll need to check the rest of the spec for other metric endpoints.
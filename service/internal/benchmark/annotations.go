@@ -0,0 +1,167 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAnnotation is returned when an annotation fails validation
+var ErrInvalidAnnotation = errors.New("invalid annotation")
+
+// ListAnnotations returns the annotations of a run, oldest first, optionally for a single item
+func (bs *BenchmarkService) ListAnnotations(runID, itemID string) ([]models.Annotation, error) {
+	annotations, err := storage.ListAnnotations(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []models.Annotation{}
+	for _, annotation := range annotations {
+		if itemID == "" || annotation.ItemID == itemID {
+			filtered = append(filtered, annotation)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.Before(filtered[j].CreatedAt)
+	})
+	return filtered, nil
+}
+
+// CreateAnnotation records a human verdict on an item of a run
+func (bs *BenchmarkService) CreateAnnotation(runID, itemID string, request models.AnnotationRequest) (*models.Annotation, error) {
+	runData, err := storage.GetRunData(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run data: %w", err)
+	}
+	canonicalID, ok := benchmarkItemID(runData.EntrySummaries, itemID)
+	if !ok {
+		return nil, fmt.Errorf("item '%s' not found in run '%s'", itemID, runID)
+	}
+	itemID = canonicalID
+
+	now := time.Now()
+	annotation := models.Annotation{
+		ID:               uuid.NewString(),
+		RunID:            runID,
+		ItemID:           itemID,
+		Annotator:        strings.TrimSpace(request.Annotator),
+		QualityRating:    request.QualityRating,
+		RelevanceCorrect: request.RelevanceCorrect,
+		Notes:            request.Notes,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := validateAnnotation(annotation); err != nil {
+		return nil, err
+	}
+
+	if err := storage.SaveAnnotation(annotation); err != nil {
+		return nil, err
+	}
+	return &annotation, nil
+}
+
+// benchmarkItemID resolves an item or entry ID to the ID the item's evaluation is keyed by in
+// benchmark results, so annotations can be attached to it
+func benchmarkItemID(entrySummaries []anpmodels.EntrySummary, id string) (string, bool) {
+	if id == "" {
+		return "", false
+	}
+	var entryMatch string
+	for _, summary := range entrySummaries {
+		itemID := summary.Results.ID
+		if itemID == "" {
+			itemID = summary.Results.Entry.ID
+		}
+		if itemID == id {
+			return itemID, true
+		}
+		if entryMatch == "" && summary.Results.Entry.ID == id {
+			entryMatch = itemID
+		}
+	}
+	return entryMatch, entryMatch != ""
+}
+
+// UpdateAnnotation applies the non-empty fields of a request to an annotation
+func (bs *BenchmarkService) UpdateAnnotation(runID, annotationID string, request models.AnnotationRequest) (*models.Annotation, error) {
+	annotation, err := storage.GetAnnotation(runID, annotationID)
+	if err != nil {
+		return nil, err
+	}
+	if annotator := strings.TrimSpace(request.Annotator); annotator != "" {
+		annotation.Annotator = annotator
+	}
+	if request.QualityRating != "" {
+		annotation.QualityRating = request.QualityRating
+	}
+	if request.RelevanceCorrect != nil {
+		annotation.RelevanceCorrect = request.RelevanceCorrect
+	}
+	if request.Notes != "" {
+		annotation.Notes = request.Notes
+	}
+	annotation.UpdatedAt = time.Now()
+	if err := validateAnnotation(*annotation); err != nil {
+		return nil, err
+	}
+
+	if err := storage.SaveAnnotation(*annotation); err != nil {
+		return nil, err
+	}
+	return annotation, nil
+}
+
+// DeleteAnnotation deletes an annotation of a run
+func (bs *BenchmarkService) DeleteAnnotation(runID, annotationID string) error {
+	return storage.DeleteAnnotation(runID, annotationID)
+}
+
+// validateAnnotation checks that an annotation is attributed and carries a verdict
+func validateAnnotation(annotation models.Annotation) error {
+	if annotation.Annotator == "" {
+		return fmt.Errorf("%w: annotator is required", ErrInvalidAnnotation)
+	}
+	if annotation.QualityRating == "" && annotation.RelevanceCorrect == nil {
+		return fmt.Errorf("%w: a quality rating or relevance verdict is required", ErrInvalidAnnotation)
+	}
+	if annotation.QualityRating != "" && ratingIndex(annotation.QualityRating) < 0 {
+		return fmt.Errorf("%w: quality rating must be one of %s", ErrInvalidAnnotation, strings.Join(qualityRatings, ", "))
+	}
+	return nil
+}
+
+// attachAnnotations adds the run's human annotations to the matching item evaluations, so they
+// can be read next to the judge's verdict
+func attachAnnotations(results *models.BenchmarkResults) {
+	annotations, err := storage.ListAnnotations(results.RunID)
+	if err != nil {
+		log.Printf("Error listing annotations for run %s: %v", results.RunID, err)
+		return
+	}
+	addAnnotations(results, annotations)
+}
+
+// addAnnotations attaches annotations to the evaluations of their items, oldest first
+func addAnnotations(results *models.BenchmarkResults, annotations []models.Annotation) {
+	sort.Slice(annotations, func(i, j int) bool {
+		return annotations[i].CreatedAt.Before(annotations[j].CreatedAt)
+	})
+	for _, annotation := range annotations {
+		eval, ok := results.DetailedEvaluations[annotation.ItemID]
+		if !ok {
+			continue
+		}
+		eval.HumanAnnotations = append(eval.HumanAnnotations, annotation)
+		results.DetailedEvaluations[annotation.ItemID] = eval
+	}
+}
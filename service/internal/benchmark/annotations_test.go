@@ -0,0 +1,104 @@
+package benchmark
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+func TestValidateAnnotation(t *testing.T) {
+	yes := true
+
+	tests := []struct {
+		name       string
+		annotation models.Annotation
+		wantErr    bool
+	}{
+		{name: "rating only", annotation: models.Annotation{Annotator: "sam", QualityRating: "Good"}},
+		{name: "relevance only", annotation: models.Annotation{Annotator: "sam", RelevanceCorrect: &yes}},
+		{name: "no annotator", annotation: models.Annotation{QualityRating: "Good"}, wantErr: true},
+		{name: "no verdict", annotation: models.Annotation{Annotator: "sam", Notes: "hmm"}, wantErr: true},
+		{name: "unknown rating", annotation: models.Annotation{Annotator: "sam", QualityRating: "Great"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAnnotation(tt.annotation)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateAnnotation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidAnnotation) {
+				t.Errorf("error %v does not wrap ErrInvalidAnnotation", err)
+			}
+		})
+	}
+}
+
+func TestAddAnnotations(t *testing.T) {
+	now := time.Now()
+	results := &models.BenchmarkResults{
+		DetailedEvaluations: map[string]models.EvaluationResult{
+			"a": {QualityRating: "Good"},
+			"b": {QualityRating: "Poor"},
+		},
+	}
+	annotations := []models.Annotation{
+		{ID: "2", ItemID: "a", QualityRating: "Fair", CreatedAt: now},
+		{ID: "1", ItemID: "a", QualityRating: "Poor", CreatedAt: now.Add(-time.Hour)},
+		{ID: "3", ItemID: "unknown", QualityRating: "Good", CreatedAt: now},
+	}
+
+	addAnnotations(results, annotations)
+
+	got := results.DetailedEvaluations["a"].HumanAnnotations
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "2" {
+		t.Errorf("item a annotations = %+v, want IDs 1 then 2", got)
+	}
+	if results.DetailedEvaluations["a"].QualityRating != "Good" {
+		t.Error("attaching annotations should not change the judge's verdict")
+	}
+	if len(results.DetailedEvaluations["b"].HumanAnnotations) != 0 {
+		t.Errorf("item b annotations = %+v, want none", results.DetailedEvaluations["b"].HumanAnnotations)
+	}
+	if _, ok := results.DetailedEvaluations["unknown"]; ok {
+		t.Error("annotations of unevaluated items should not add evaluations")
+	}
+}
+
+func TestBenchmarkItemID(t *testing.T) {
+	entrySummaries := []anpmodels.EntrySummary{
+		{Results: anpmodels.Item{ID: "item-1", Entry: anpmodels.Entry{ID: "entry-1"}}},
+		{Results: anpmodels.Item{Entry: anpmodels.Entry{ID: "entry-2"}}},
+		{Results: anpmodels.Item{ID: "entry-1"}},
+	}
+
+	tests := []struct {
+		id     string
+		want   string
+		wantOK bool
+	}{
+		{id: "item-1", want: "item-1", wantOK: true},
+		{id: "entry-2", want: "entry-2", wantOK: true},
+		// Item IDs take precedence over the entry IDs of other items
+		{id: "entry-1", want: "entry-1", wantOK: true},
+		{id: "missing"},
+		{id: ""},
+	}
+	for _, tt := range tests {
+		got, ok := benchmarkItemID(entrySummaries, tt.id)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("benchmarkItemID(%q) = %q, %v, want %q, %v", tt.id, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	// An annotation made by entry ID is attached to the evaluation keyed by item ID
+	summaries := entrySummaries[:1]
+	itemID, _ := benchmarkItemID(summaries, "entry-1")
+	results := &models.BenchmarkResults{DetailedEvaluations: map[string]models.EvaluationResult{"item-1": {}}}
+	addAnnotations(results, []models.Annotation{{ID: "1", ItemID: itemID, QualityRating: "Good"}})
+	if len(results.DetailedEvaluations["item-1"].HumanAnnotations) != 1 {
+		t.Errorf("annotation by entry ID was not attached to item-1: %+v", results.DetailedEvaluations)
+	}
+}
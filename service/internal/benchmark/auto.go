@@ -0,0 +1,112 @@
+package benchmark
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// Auto-benchmark policies
+const (
+	AutoBenchmarkOff      = "off"
+	AutoBenchmarkAll      = "all"
+	AutoBenchmarkPersonas = "personas"
+	AutoBenchmarkEveryN   = "every_n"
+)
+
+// autoBenchmarkPolicy decides which submitted runs are benchmarked without a manual request
+type autoBenchmarkPolicy struct {
+	mode     string
+	personas []string // Opted-in personas for the personas policy
+	everyN   int      // Interval for the every_n policy
+}
+
+// enabled reports whether any run could be benchmarked automatically
+func (p autoBenchmarkPolicy) enabled() bool {
+	return p.mode != "" && p.mode != AutoBenchmarkOff
+}
+
+// applies reports whether a run should be benchmarked. personaRuns is the number of distinct runs
+// ever submitted for the persona including this one, so every_n picks the Nth, 2Nth, ... run of each persona.
+func (p autoBenchmarkPolicy) applies(personaName string, personaRuns int) bool {
+	switch p.mode {
+	case AutoBenchmarkAll:
+		return true
+	case AutoBenchmarkPersonas:
+		for _, name := range p.personas {
+			if name == personaName {
+				return true
+			}
+		}
+		return false
+	case AutoBenchmarkEveryN:
+		return p.everyN > 0 && personaRuns > 0 && personaRuns%p.everyN == 0
+	default:
+		return false
+	}
+}
+
+// AutoBenchmark queues a benchmark for a newly stored run if the auto-benchmark policy selects it.
+// It returns nil when the run is not selected.
+func (bs *BenchmarkService) AutoBenchmark(runData models.PersistedRunData) (*models.BenchmarkResponse, error) {
+	if !bs.autoBenchmark.enabled() {
+		return nil, nil
+	}
+
+	// Stored runs expire, so every_n counts with a persisted counter rather than the runs still held
+	personaRuns := 0
+	if bs.autoBenchmark.mode == AutoBenchmarkEveryN {
+		count, counted, err := storage.CountPersonaRun(runData.Persona.Name, runData.RunID)
+		if err != nil {
+			return nil, err
+		}
+		if !counted {
+			return nil, nil // A resubmitted run was already considered when it was first stored
+		}
+		personaRuns = count
+	}
+
+	if !bs.autoBenchmark.applies(runData.Persona.Name, personaRuns) {
+		return nil, nil
+	}
+
+	log.Printf("Auto-benchmarking run %s for persona %s (policy: %s)", runData.RunID, runData.Persona.Name, bs.autoBenchmark.mode)
+	return bs.CreateBenchmark(runData.RunID, models.CreateBenchmarkRequest{})
+}
+
+// BenchmarkLatestRun queues a benchmark for the most recent run of a persona that has no benchmark yet.
+// It returns nil when every run of the persona has already been benchmarked.
+func (bs *BenchmarkService) BenchmarkLatestRun(personaName string) (*models.BenchmarkResponse, error) {
+	runs, err := storage.ListRunMetadata(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	benchmarks, err := storage.ListBenchmarkResults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list benchmark results: %w", err)
+	}
+
+	benchmarked := make(map[string]bool, len(benchmarks))
+	for _, results := range benchmarks {
+		benchmarked[results.RunID] = true
+	}
+
+	var candidates []models.RunMetadata
+	for _, run := range runs {
+		if run.PersonaName == personaName && !benchmarked[run.ID] {
+			candidates = append(candidates, run)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].RunDate.After(candidates[j].RunDate)
+	})
+
+	log.Printf("Benchmarking latest unbenchmarked run %s for persona %s", candidates[0].ID, personaName)
+	return bs.CreateBenchmark(candidates[0].ID, models.CreateBenchmarkRequest{})
+}
@@ -0,0 +1,31 @@
+package benchmark
+
+import "testing"
+
+func TestAutoBenchmarkPolicyApplies(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      autoBenchmarkPolicy
+		persona     string
+		personaRuns int
+		want        bool
+	}{
+		{name: "off", policy: autoBenchmarkPolicy{mode: AutoBenchmarkOff}, persona: "a", personaRuns: 1, want: false},
+		{name: "unset", policy: autoBenchmarkPolicy{}, persona: "a", personaRuns: 1, want: false},
+		{name: "all", policy: autoBenchmarkPolicy{mode: AutoBenchmarkAll}, persona: "a", want: true},
+		{name: "opted-in persona", policy: autoBenchmarkPolicy{mode: AutoBenchmarkPersonas, personas: []string{"a", "b"}}, persona: "b", want: true},
+		{name: "other persona", policy: autoBenchmarkPolicy{mode: AutoBenchmarkPersonas, personas: []string{"a"}}, persona: "c", want: false},
+		{name: "every third, first run", policy: autoBenchmarkPolicy{mode: AutoBenchmarkEveryN, everyN: 3}, persona: "a", personaRuns: 1, want: false},
+		{name: "every third, third run", policy: autoBenchmarkPolicy{mode: AutoBenchmarkEveryN, everyN: 3}, persona: "a", personaRuns: 3, want: true},
+		{name: "every third, sixth run", policy: autoBenchmarkPolicy{mode: AutoBenchmarkEveryN, everyN: 3}, persona: "a", personaRuns: 6, want: true},
+		{name: "every run", policy: autoBenchmarkPolicy{mode: AutoBenchmarkEveryN, everyN: 1}, persona: "a", personaRuns: 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.applies(tt.persona, tt.personaRuns); got != tt.want {
+				t.Errorf("applies(%q, %d) = %v, want %v", tt.persona, tt.personaRuns, got, tt.want)
+			}
+		})
+	}
+}
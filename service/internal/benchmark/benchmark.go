@@ -2,14 +2,15 @@ package benchmark
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/bakkerme/ai-news-auditability-service/internal/customerrors"
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
 	"github.com/bakkerme/ai-news-auditability-service/internal/models"
 	"github.com/bakkerme/ai-news-auditability-service/internal/openai"
 	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
@@ -17,6 +18,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// evaluationRubricVersion identifies the evaluation prompt below. Bump it whenever the
+// prompt or schema changes so that verdicts from different rubrics are not mixed.
+const evaluationRubricVersion = "1"
+
 const evaluationPrompt = `You are an expert in evaluating AI-generated content. Your task is to evaluate the quality of the following post summary, focusing purely on how well it summarizes and analyzes the content.
 
 The persona is {{.PersonaIdentity}}
@@ -58,18 +63,66 @@ Respond with a JSON object containing:
 
 // BenchmarkService handles benchmark operations
 type BenchmarkService struct {
-	llmURL    string
-	llmAPIKey string
-	llmModel  string
+	judges            []internal.JudgeConfig
+	aggregation       string
+	samples           int     // Self-consistency samples per judge
+	sampleTemperature float64 // Temperature used when samples > 1
+	minConfidence     float64 // Verdicts below this confidence are flagged for review
+	embeddingURL      string
+	embeddingAPIKey   string
+	embeddingModel    string // Empty disables semantic similarity
+	matcher           EntryMatcher
+	prices            map[string]internal.ModelPrice // USD per million tokens, by model
+	defaultBudget     models.BenchmarkBudget
+	autoBenchmark     autoBenchmarkPolicy
+	events            *events.Bus // Nil discards events
 }
 
-// NewBenchmarkService creates a new benchmark service
-func NewBenchmarkService(llmURL, llmAPIKey, llmModel string) *BenchmarkService {
+// NewBenchmarkService creates a new benchmark service using the judge panel from the specification
+func NewBenchmarkService(spec *internal.Specification) *BenchmarkService {
 	return &BenchmarkService{
-		llmURL:    llmURL,
-		llmAPIKey: llmAPIKey,
-		llmModel:  llmModel,
+		judges:            spec.Judges,
+		aggregation:       spec.JudgeAggregation,
+		samples:           spec.JudgeSamples,
+		sampleTemperature: spec.JudgeSampleTemp,
+		minConfidence:     spec.JudgeMinConfidence,
+		embeddingURL:      spec.LlmURL,
+		embeddingAPIKey:   spec.LlmAPIKey,
+		embeddingModel:    spec.EmbeddingModel,
+		matcher:           newEntryMatcher(spec.EntryIDPatterns),
+		prices:            spec.Prices,
+		autoBenchmark: autoBenchmarkPolicy{
+			mode:     spec.AutoBenchmarkPolicy,
+			personas: spec.AutoBenchmarkPersonas,
+			everyN:   spec.AutoBenchmarkEveryN,
+		},
+		defaultBudget: models.BenchmarkBudget{
+			MaxItems:           spec.BenchmarkMaxItems,
+			MaxTokens:          spec.BenchmarkMaxTokens,
+			MaxCost:            spec.BenchmarkMaxCost,
+			MaxDurationSeconds: int(spec.BenchmarkMaxDuration.Seconds()),
+		},
+	}
+}
+
+// newEntryMatcher builds the pattern matcher, falling back to the default patterns if the configured ones are invalid
+func newEntryMatcher(patterns []string) EntryMatcher {
+	matcher, err := NewPatternEntryMatcher(patterns)
+	if err != nil {
+		log.Printf("Error building entry matcher, using default patterns: %v", err)
+		matcher, _ = NewPatternEntryMatcher(DefaultEntryIDPatterns)
 	}
+	return matcher
+}
+
+// SetEntryMatcher replaces the matcher used to pair processed items with their raw input
+func (bs *BenchmarkService) SetEntryMatcher(matcher EntryMatcher) {
+	bs.matcher = matcher
+}
+
+// SetEventBus sets the bus that benchmark lifecycle events are published on
+func (bs *BenchmarkService) SetEventBus(bus *events.Bus) {
+	bs.events = bus
 }
 
 // EvaluationResult represents the structure of the benchmark evaluation response
@@ -107,7 +160,11 @@ var EvaluationResultSchema = map[string]interface{}{
 }
 
 // CreateBenchmark creates a new benchmark for the given run ID
-func (bs *BenchmarkService) CreateBenchmark(runID string) (*models.BenchmarkResponse, error) {
+func (bs *BenchmarkService) CreateBenchmark(runID string, request models.CreateBenchmarkRequest) (*models.BenchmarkResponse, error) {
+	if err := validateSampling(request.Sampling); err != nil {
+		return nil, err
+	}
+
 	// Check if the run exists
 	runData, err := storage.GetRunData(runID)
 	if err != nil {
@@ -126,36 +183,27 @@ func (bs *BenchmarkService) CreateBenchmark(runID string) (*models.BenchmarkResp
 	}
 
 	// Start benchmark processing in a goroutine
-	go bs.processBenchmark(benchmarkID, runID, runData)
+	go bs.processBenchmark(benchmarkID, runID, runData, request)
 
 	return response, nil
 }
 
 // processBenchmark performs the actual benchmark evaluation
-func (bs *BenchmarkService) processBenchmark(benchmarkID, runID string, runData *models.PersistedRunData) {
+func (bs *BenchmarkService) processBenchmark(benchmarkID, runID string, runData *models.PersistedRunData, request models.CreateBenchmarkRequest) {
+	start := time.Now()
 	log.Printf("Starting benchmark processing for run ID: %s, benchmark ID: %s", runID, benchmarkID)
 
-	// Initialize OpenAI client
-	llmClient := openai.New(bs.llmURL, bs.llmAPIKey, bs.llmModel)
+	// Initialize an LLM client for each judge on the panel
+	panel := bs.newJudgePanel()
 
 	// Generate evaluation prompt with persona-specific information
-	tmpl, err := template.New("evaluation").Parse(evaluationPrompt)
+	fullPrompt, err := renderEvaluationPrompt(runData)
 	if err != nil {
-		log.Printf("Error parsing evaluation prompt template: %v", err)
-		bs.saveBenchmarkError(benchmarkID, runID, "Failed to parse evaluation prompt", err)
+		log.Printf("Error rendering evaluation prompt: %v", err)
+		bs.saveBenchmarkError(benchmarkID, runID, "Failed to render evaluation prompt", err)
 		return
 	}
 
-	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, runData.Persona)
-	if err != nil {
-		log.Printf("Error executing evaluation prompt template: %v", err)
-		bs.saveBenchmarkError(benchmarkID, runID, "Failed to execute evaluation prompt", err)
-		return
-	}
-
-	fullPrompt := buf.String()
-
 	// Prepare benchmark results
 	results := &models.BenchmarkResults{
 		BenchmarkID:         benchmarkID,
@@ -165,152 +213,330 @@ func (bs *BenchmarkService) processBenchmark(benchmarkID, runID string, runData
 		DetailedEvaluations: make(map[string]models.EvaluationResult),
 		MissingItems:        make([]string, 0),
 		Timestamp:           time.Now(),
+		Judging:             bs.judgeSettings(),
+		CacheBypassed:       request.BypassCache,
+		Status:              models.BenchmarkStatusCompleted,
+	}
+	budget := bs.effectiveBudget(request.Budget)
+	if budget != (models.BenchmarkBudget{}) {
+		results.Budget = &budget
+	}
+	tracker := newBudgetTracker(budget)
+
+	// Semantic similarity needs an embedding model and, for drift, the previous run of this persona
+	evalCtx := evaluationContext{
+		panel:        panel,
+		systemPrompt: fullPrompt,
+		embedder:     bs.newEmbedder(),
+	}
+	if !request.BypassCache {
+		evalCtx.cache = &judgeCache{}
+	}
+	if evalCtx.embedder != nil {
+		evalCtx.previousRunID, evalCtx.previousItems = previousRunItems(runData)
 	}
 
-	// Build a map from ID to raw input for matching
-	rawInputByID := make(map[string]string)
-	processedIDs := make(map[string]bool)
+	// Pair each processed item with the raw input it was generated from
+	report := bs.matcher.Match(runData.EntrySummaries)
+	results.UnmatchedItems = report.Unmatched
+	results.MatchDiagnostics = matchDiagnostics(report, bs.matcher)
+	if report.UnidentifiedInputs > 0 {
+		log.Printf("Warning: %d raw input(s) had no identifiable entry ID", report.UnidentifiedInputs)
+	}
 
-	// Extract IDs from the raw input in overall summaries
-	for _, summary := range runData.EntrySummaries {
-		// Try to extract the ID from the raw input (assuming 'ID: <id>' is present)
-		lines := strings.Split(summary.RawInput, "\n")
-		var id string
-		for _, line := range lines {
-			if strings.HasPrefix(line, "ID: ") {
-				id = strings.TrimSpace(strings.TrimPrefix(line, "ID: "))
-				break
+	// Judge only the sampled items when sampling was requested
+	inSample := func(string) bool { return true }
+	if request.Sampling != nil {
+		var selected map[string]bool
+		selected, results.Sampling = selectSample(*request.Sampling, report)
+		inSample = func(id string) bool { return selected[id] }
+		log.Printf("Sampling %d of %d items (seed %d)", results.Sampling.SampleSize, results.Sampling.PopulationSize, results.Sampling.Spec.Seed)
+
+		var sampledUnmatched []string
+		for _, id := range results.UnmatchedItems {
+			if selected[id] {
+				sampledUnmatched = append(sampledUnmatched, id)
 			}
 		}
-		if id != "" {
-			rawInputByID[id] = summary.RawInput
-		}
+		results.UnmatchedItems = sampledUnmatched
 	}
 
-	// Process each item in the benchmark data
-	for _, result := range runData.EntrySummaries {
-		if result.Results.ID == "" {
-			log.Printf("Warning: Empty ID for result")
+	// Process each item in the benchmark data. Every item ends in an explicit state.
+	for _, id := range report.ItemOrder {
+		if !inSample(id) {
 			continue
 		}
-
-		processedIDs[result.Results.ID] = true
-		log.Printf("Processing entry (ID: %s)...", result.Results.ID)
-
-		// Find the matching raw input by ID
-		rawInput, ok := rawInputByID[result.Results.ID]
+		rawInput, ok := report.Sources[id]
 		if !ok {
-			log.Printf("Warning: No matching raw input for result ID: %s", result.Results.ID)
+			log.Printf("Warning: No matching raw input for result ID: %s", id)
+			results.DetailedEvaluations[id] = models.EvaluationResult{
+				Status: models.ItemStatusNoSource,
+				Error:  "No matching raw input was found for this item",
+			}
 			continue
 		}
 
-		// Create evaluation input
-		evaluationInput := fmt.Sprintf("Source Material:\n%s\n\nGenerated Summary:\n%s\n",
-			rawInput,
-			bs.formatSummary(result.Results))
-
-		// Call LLM for evaluation
-		log.Printf("Calling LLM for evaluation of entry ID: %s...", result.Results.ID)
-		resultChan := make(chan customerrors.ErrorString, 1)
-		bs.chatCompletionForBenchmarkEvaluation(llmClient, fullPrompt, []string{evaluationInput}, resultChan)
-		evalResponse := <-resultChan
-		if evalResponse.Err != nil {
-			log.Printf("Error evaluating entry %s: %v", result.Results.ID, evalResponse.Err)
+		// Once the budget runs out, the remaining items are recorded as skipped
+		if results.BudgetExceeded == "" {
+			results.BudgetExceeded = tracker.exceeded()
+			if results.BudgetExceeded != "" {
+				log.Printf("Benchmark budget exceeded (%s), skipping remaining items", results.BudgetExceeded)
+				results.Status = models.BenchmarkStatusBudgetExceeded
+			}
+		}
+		if results.BudgetExceeded != "" {
+			results.DetailedEvaluations[id] = models.EvaluationResult{
+				Status:      models.ItemStatusSkipped,
+				Error:       "Benchmark budget exceeded: " + results.BudgetExceeded,
+				MatchMethod: report.Methods[id],
+			}
 			continue
 		}
 
-		// Parse evaluation result
-		var evalResult EvaluationResult
-		jsonStr := llmClient.PreprocessJSON(evalResponse.Value)
-		err = json.Unmarshal([]byte(jsonStr), &evalResult)
-		if err != nil {
-			log.Printf("Error parsing evaluation result for %s: %v", result.Results.ID, err)
+		log.Printf("Processing entry (ID: %s, matched by %s)...", id, report.Methods[id])
+		eval := bs.evaluateItem(evalCtx, id, report.Items[id], rawInput)
+		eval.MatchMethod = report.Methods[id]
+		results.DetailedEvaluations[id] = eval
+		tracker.record(eval)
+	}
+
+	// Raw inputs with no processed item were dropped by the processor
+	for _, id := range report.Dropped {
+		if !inSample(id) {
 			continue
 		}
+		log.Printf("Found missing item (ID: %s)...", id)
+		results.MissingItems = append(results.MissingItems, id)
+
+		// Add a Poor rating evaluation for the missing item
+		results.DetailedEvaluations[id] = models.EvaluationResult{
+			QualityRating:        "Poor",
+			QualityExplanation:   "Item was present in raw input but missing from processed results",
+			RelevanceCorrect:     false,
+			RelevanceExplanation: "Unable to assess relevance as item was not processed",
+			Status:               models.ItemStatusMissingFromOutput,
+		}
+	}
 
-		log.Printf("Evaluation for entry ID %s: Quality Rating = %s, Relevance Correct = %v",
-			result.Results.ID, evalResult.QualityRating, evalResult.RelevanceCorrect)
+	// Calculate aggregate metrics
+	log.Println("Calculating aggregate metrics...")
+	computeAggregates(results)
+	if evalCtx.cache != nil {
+		results.CacheHits = int(evalCtx.cache.hits.Load())
+		results.CacheMisses = int(evalCtx.cache.misses.Load())
+		log.Printf("Judge cache: %d hit(s), %d miss(es)", results.CacheHits, results.CacheMisses)
+	}
+	log.Printf("Scored %d of %d items (%.1f%% coverage), %d errored, %d skipped",
+		results.TotalItems-len(results.ErroredItems)-len(results.SkippedItems), results.TotalItems, results.Coverage,
+		len(results.ErroredItems), len(results.SkippedItems))
+	embeddingModel := ""
+	if evalCtx.embedder != nil {
+		embeddingModel = bs.embeddingModel
+	}
+	summariseResults(results, len(panel), evalCtx.previousRunID, embeddingModel)
+	results.Usage = summariseUsage(results, bs.prices)
+	results.Usage.DurationMs = time.Since(start).Milliseconds()
+	log.Printf("Benchmark usage: %d request(s), %d tokens, estimated cost $%.4f",
+		results.Usage.Total.Requests, results.Usage.Total.TotalTokens, results.Usage.Total.EstimatedCost)
 
-		// Convert to models.EvaluationResult
-		modelEvalResult := models.EvaluationResult{
-			QualityRating:        evalResult.QualityRating,
-			QualityExplanation:   evalResult.QualityExplanation,
-			RelevanceCorrect:     evalResult.RelevanceCorrect,
-			RelevanceExplanation: evalResult.RelevanceExplanation,
-		}
+	// Save benchmark results
+	err = bs.saveBenchmarkResults(benchmarkID, results)
+	if err != nil {
+		log.Printf("Error saving benchmark results: %v", err)
+		bs.saveBenchmarkError(benchmarkID, runID, "Failed to save benchmark results", err)
+		return
+	}
+
+	log.Printf("Benchmark processing %s for run ID: %s, benchmark ID: %s", results.Status, runID, benchmarkID)
+	bs.events.Publish(events.BenchmarkCompleted, *results)
+}
 
-		results.DetailedEvaluations[result.Results.ID] = modelEvalResult
-		results.TotalItems++
+// renderEvaluationPrompt fills the evaluation rubric with the run's persona
+func renderEvaluationPrompt(runData *models.PersistedRunData) (string, error) {
+	tmpl, err := template.New("evaluation").Parse(evaluationPrompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse evaluation prompt: %w", err)
 	}
 
-	// Check for missing items
-	for id := range rawInputByID {
-		if !processedIDs[id] {
-			log.Printf("Found missing item (ID: %s)...", id)
-			results.MissingItems = append(results.MissingItems, id)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, runData.Persona); err != nil {
+		return "", fmt.Errorf("failed to execute evaluation prompt: %w", err)
+	}
+	return buf.String(), nil
+}
 
-			// Add a Poor rating evaluation for the missing item
-			results.DetailedEvaluations[id] = models.EvaluationResult{
-				QualityRating:        "Poor",
-				QualityExplanation:   "Item was present in raw input but missing from processed results",
-				RelevanceCorrect:     false,
-				RelevanceExplanation: "Unable to assess relevance as item was not processed",
-			}
-			results.TotalItems++
+// summariseResults computes the benchmark-level metrics that are derived from the per-item judge-free metrics.
+// An empty embeddingModel means semantic similarity was not computed.
+func summariseResults(results *models.BenchmarkResults, judgeCount int, previousRunID, embeddingModel string) {
+	results.LexicalSummary = averageLexicalMetrics(results.DetailedEvaluations)
+	results.HallucinationRate, results.HallucinatedItems = hallucinationSummary(results.DetailedEvaluations)
+	if embeddingModel != "" {
+		results.SemanticSummary = summariseSemantic(results.DetailedEvaluations, previousRunID, embeddingModel)
+	}
+	if judgeCount > 1 {
+		results.JudgeAgreement = judgeAgreement(results.DetailedEvaluations, judgeCount)
+		log.Printf("Judge agreement: quality kappa = %.3f, relevance kappa = %.3f over %d items",
+			results.JudgeAgreement.QualityKappa, results.JudgeAgreement.RelevanceKappa, results.JudgeAgreement.ItemsRated)
+	}
+}
+
+// evaluationContext holds what is shared by every item evaluation in a benchmark
+type evaluationContext struct {
+	panel         []judge
+	systemPrompt  string
+	embedder      openai.OpenAIClient // Nil when semantic similarity is disabled
+	cache         *judgeCache         // Nil bypasses the judge response cache
+	previousRunID string
+	previousItems map[string]anpmodels.Item
+}
+
+// evaluateItem computes the judge-free metrics for an item and asks the panel to judge it.
+// The result's Status records whether a verdict was produced.
+func (bs *BenchmarkService) evaluateItem(evalCtx evaluationContext, id string, item anpmodels.Item, rawInput string) models.EvaluationResult {
+	start := time.Now()
+	var usage models.ItemUsage
+
+	// Create evaluation input
+	evaluationInput := fmt.Sprintf("Source Material:\n%s\n\nGenerated Summary:\n%s\n",
+		rawInput,
+		bs.formatSummary(item))
+
+	// Judge-free metrics against the source material
+	lexical := computeLexicalMetrics(rawInput, item.Summary)
+	hallucination := checkHallucinations(rawInput, item.Summary)
+
+	var semantic *models.SemanticSimilarity
+	if evalCtx.embedder != nil {
+		var previous *anpmodels.Item
+		if previousItem, ok := evalCtx.previousItems[id]; ok {
+			previous = &previousItem
+		}
+		var err error
+		semantic, usage.Embedding, err = bs.semanticSimilarity(evalCtx.embedder, rawInput, item.Summary, previous, evalCtx.previousRunID)
+		if err != nil {
+			log.Printf("Error computing semantic similarity for entry %s: %v", id, err)
 		}
 	}
 
-	// Calculate aggregate metrics
-	log.Println("Calculating aggregate metrics...")
-	var correctRelevance int
-	for _, eval := range results.DetailedEvaluations {
-		if eval.RelevanceCorrect {
-			correctRelevance++
+	// Call each judge for evaluation
+	log.Printf("Calling %d judge(s) for evaluation of entry ID: %s...", len(evalCtx.panel), id)
+	verdicts := bs.evaluateWithPanel(evalCtx.panel, evalCtx.cache, evalCtx.systemPrompt, evaluationInput)
+	for _, v := range verdicts {
+		if v.Usage != nil {
+			addUsage(&usage.Judging, *v.Usage)
 		}
 	}
+	eval, ok := combineVerdicts(verdicts, bs.aggregation)
+	if ok {
+		eval.Status = models.ItemStatusEvaluated
+		eval.LowConfidence = eval.Confidence < bs.minConfidence
+		log.Printf("Evaluation for entry ID %s: Quality Rating = %s, Relevance Correct = %v, Confidence = %.2f, Disagreement = %.2f",
+			id, eval.QualityRating, eval.RelevanceCorrect, eval.Confidence, eval.Disagreement)
+	} else {
+		eval = failedEvaluation(verdicts)
+		log.Printf("Error evaluating entry %s (%s): %s", id, eval.Status, eval.Error)
+	}
 
-	if results.TotalItems > 0 {
-		results.RelevanceAccuracy = float64(correctRelevance) / float64(results.TotalItems)
-
-		// Calculate quality score with Poor rated at 0%
-		var totalQualityScore float64
-		for _, eval := range results.DetailedEvaluations {
-			switch eval.QualityRating {
-			case "Excellent":
-				totalQualityScore += 100.0
-			case "Good":
-				totalQualityScore += 75.0
-			case "Fair":
-				totalQualityScore += 50.0
-			case "Poor":
-				totalQualityScore += 0.0
-			}
+	eval.Lexical = &lexical
+	eval.Hallucination = &hallucination
+	eval.Semantic = semantic
+	eval.EvaluatedAt = time.Now()
+	usage.LatencyMs = time.Since(start).Milliseconds()
+	eval.Usage = &usage
+	return eval
+}
+
+// matchDiagnostics summarises a match report for the benchmark results
+func matchDiagnostics(report MatchReport, matcher EntryMatcher) *models.MatchDiagnostics {
+	diagnostics := &models.MatchDiagnostics{
+		Methods:            make(map[string]int),
+		UnmatchedItems:     len(report.Unmatched),
+		DroppedItems:       len(report.Dropped),
+		UnidentifiedInputs: report.UnidentifiedInputs,
+		Patterns:           matcher.Describe(),
+	}
+	for _, method := range report.Methods {
+		diagnostics.Methods[method]++
+	}
+	return diagnostics
+}
+
+// isScored reports whether an evaluation counts towards the scores. Results saved
+// before item states were recorded have no status and were always scored.
+func isScored(eval models.EvaluationResult) bool {
+	switch eval.Status {
+	case "", models.ItemStatusEvaluated, models.ItemStatusMissingFromOutput:
+		return true
+	default:
+		return false
+	}
+}
+
+// computeAggregates calculates relevance accuracy, quality score and coverage from the detailed evaluations.
+// Items that could not be evaluated are listed in ErroredItems, and items skipped by the budget
+// in SkippedItems; both are left out of the scores.
+func computeAggregates(results *models.BenchmarkResults) {
+	results.RelevanceAccuracy = 0
+	results.QualityScore = 0
+	results.Coverage = 0
+	results.QualityScoreCI, results.RelevanceAccuracyCI = nil, nil
+	results.EvaluatedItems = 0
+	results.ErroredItems = nil
+	results.SkippedItems = nil
+	results.LowConfidenceItems = nil
+	results.TotalItems = len(results.DetailedEvaluations)
+
+	var scored, correctRelevance int
+	var totalQualityScore float64
+	for id, eval := range results.DetailedEvaluations {
+		if eval.Status == models.ItemStatusSkipped {
+			results.SkippedItems = append(results.SkippedItems, id)
+			continue
+		}
+		if !isScored(eval) {
+			results.ErroredItems = append(results.ErroredItems, id)
+			continue
 		}
-		results.QualityScore = totalQualityScore / float64(results.TotalItems)
+		if eval.Status == models.ItemStatusEvaluated {
+			results.EvaluatedItems++
+		}
+		if eval.LowConfidence {
+			results.LowConfidenceItems = append(results.LowConfidenceItems, id)
+		}
+		scored++
+		if eval.RelevanceCorrect {
+			correctRelevance++
+		}
+		totalQualityScore += ratingScore(eval.QualityRating)
 	}
+	sort.Strings(results.ErroredItems)
+	sort.Strings(results.SkippedItems)
+	sort.Strings(results.LowConfidenceItems)
 
-	// Save benchmark results
-	err = bs.saveBenchmarkResults(benchmarkID, results)
-	if err != nil {
-		log.Printf("Error saving benchmark results: %v", err)
-		bs.saveBenchmarkError(benchmarkID, runID, "Failed to save benchmark results", err)
+	if results.TotalItems == 0 {
+		return
+	}
+	results.Coverage = float64(scored) / float64(results.TotalItems) * 100
+	if scored == 0 {
 		return
 	}
+	results.RelevanceAccuracy = float64(correctRelevance) / float64(scored)
+	results.QualityScore = totalQualityScore / float64(scored)
 
-	log.Printf("Benchmark processing completed for run ID: %s, benchmark ID: %s", runID, benchmarkID)
+	// Scores are estimates from a limited number of items, so report how precise they are
+	results.QualityScoreCI, results.RelevanceAccuracyCI = scoreIntervals(results.DetailedEvaluations)
 }
 
-// chatCompletionForBenchmarkEvaluation queries the LLM for a benchmark evaluation
-func (bs *BenchmarkService) chatCompletionForBenchmarkEvaluation(llmClient openai.OpenAIClient, systemPrompt string, userPrompts []string, results chan customerrors.ErrorString) {
+// chatCompletionForBenchmarkEvaluation queries the LLM for a benchmark evaluation.
+// A temperature of 0.0 is used for single-sample evaluations; self-consistency
+// sampling passes a higher temperature so that samples can differ.
+func (bs *BenchmarkService) chatCompletionForBenchmarkEvaluation(llmClient openai.OpenAIClient, systemPrompt string, userPrompts []string, temperature float64, results chan openai.CompletionResult) {
 	schemaParams := &openai.SchemaParameters{
 		Schema:      EvaluationResultSchema,
 		Name:        "benchmark_evaluation",
 		Description: "an object representing a benchmark evaluation result (quality and relevance)",
 	}
 
-	// Setting temperature to 0.0 for more consistent evaluations
-	temperature := 0.0
-
 	llmClient.ChatCompletion(
 		systemPrompt,
 		userPrompts,
@@ -347,14 +573,22 @@ func (bs *BenchmarkService) saveBenchmarkError(benchmarkID, runID, message strin
 		RunID:         runID,
 		Timestamp:     time.Now(),
 		FailureReason: fmt.Sprintf("%s: %v", message, err),
+		Status:        models.BenchmarkStatusFailed,
 	}
 	
 	if saveErr := storage.SaveBenchmarkResults(benchmarkID, *errorResults); saveErr != nil {
 		log.Printf("Failed to save benchmark error: %v", saveErr)
 	}
+	bs.events.Publish(events.BenchmarkFailed, *errorResults)
 }
 
-// GetBenchmarkResults retrieves benchmark results by benchmark ID
+// GetBenchmarkResults retrieves benchmark results by run ID, with any human annotations
+// attached to their items
 func (bs *BenchmarkService) GetBenchmarkResults(runID string) (*models.BenchmarkResults, error) {
-	return storage.GetBenchmarkResults(runID)
+	results, err := storage.GetBenchmarkResults(runID)
+	if err != nil {
+		return nil, err
+	}
+	attachAnnotations(results)
+	return results, nil
 }
\ No newline at end of file
@@ -0,0 +1,81 @@
+package benchmark
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestComputeAggregatesExcludesErroredItems(t *testing.T) {
+	results := &models.BenchmarkResults{
+		DetailedEvaluations: map[string]models.EvaluationResult{
+			"a": {Status: models.ItemStatusEvaluated, QualityRating: "Excellent", RelevanceCorrect: true},
+			"b": {Status: models.ItemStatusEvaluated, QualityRating: "Fair", RelevanceCorrect: false, LowConfidence: true},
+			"c": {Status: models.ItemStatusMissingFromOutput, QualityRating: "Poor"},
+			"d": {Status: models.ItemStatusJudgeError, Error: "timeout"},
+			"e": {Status: models.ItemStatusNoSource},
+		},
+	}
+
+	computeAggregates(results)
+
+	if results.TotalItems != 5 {
+		t.Errorf("TotalItems = %d, want 5", results.TotalItems)
+	}
+	if results.EvaluatedItems != 2 {
+		t.Errorf("EvaluatedItems = %d, want 2", results.EvaluatedItems)
+	}
+	if !reflect.DeepEqual(results.ErroredItems, []string{"d", "e"}) {
+		t.Errorf("ErroredItems = %v, want [d e]", results.ErroredItems)
+	}
+	if !reflect.DeepEqual(results.LowConfidenceItems, []string{"b"}) {
+		t.Errorf("LowConfidenceItems = %v, want [b]", results.LowConfidenceItems)
+	}
+	if results.Coverage != 60 {
+		t.Errorf("Coverage = %v, want 60", results.Coverage)
+	}
+	if results.QualityScore != 50 {
+		t.Errorf("QualityScore = %v, want 50", results.QualityScore)
+	}
+	if want := 1.0 / 3.0; results.RelevanceAccuracy != want {
+		t.Errorf("RelevanceAccuracy = %v, want %v", results.RelevanceAccuracy, want)
+	}
+}
+
+func TestFailedEvaluationPrefersParseErrors(t *testing.T) {
+	eval := failedEvaluation([]models.JudgeVerdict{
+		{Judge: "a", Error: "judge call failed: timeout", Status: models.ItemStatusJudgeError},
+		{Judge: "b", Error: "could not parse judge response: bad JSON", Status: models.ItemStatusParseError},
+	})
+	if eval.Status != models.ItemStatusParseError {
+		t.Errorf("Status = %q, want %q", eval.Status, models.ItemStatusParseError)
+	}
+	if eval.Error != "b: could not parse judge response: bad JSON" {
+		t.Errorf("Error = %q", eval.Error)
+	}
+	if len(eval.JudgeVerdicts) != 2 {
+		t.Errorf("JudgeVerdicts = %d, want 2", len(eval.JudgeVerdicts))
+	}
+}
+
+func TestComputeAggregatesListsSkippedItems(t *testing.T) {
+	results := &models.BenchmarkResults{
+		DetailedEvaluations: map[string]models.EvaluationResult{
+			"a": {Status: models.ItemStatusEvaluated, QualityRating: "Good", RelevanceCorrect: true},
+			"b": {Status: models.ItemStatusSkipped},
+		},
+	}
+
+	computeAggregates(results)
+
+	if !reflect.DeepEqual(results.SkippedItems, []string{"b"}) {
+		t.Errorf("SkippedItems = %v, want [b]", results.SkippedItems)
+	}
+	if len(results.ErroredItems) != 0 {
+		t.Errorf("ErroredItems = %v, want none", results.ErroredItems)
+	}
+	if results.Coverage != 50 || results.QualityScore != 75 {
+		t.Errorf("Coverage = %v, QualityScore = %v, want 50 and 75", results.Coverage, results.QualityScore)
+	}
+}
@@ -0,0 +1,66 @@
+package benchmark
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// effectiveBudget overlays any limits set on the request onto the configured defaults
+func (bs *BenchmarkService) effectiveBudget(requested *models.BenchmarkBudget) models.BenchmarkBudget {
+	budget := bs.defaultBudget
+	if requested == nil {
+		return budget
+	}
+	if requested.MaxItems > 0 {
+		budget.MaxItems = requested.MaxItems
+	}
+	if requested.MaxTokens > 0 {
+		budget.MaxTokens = requested.MaxTokens
+	}
+	if requested.MaxCost > 0 {
+		budget.MaxCost = requested.MaxCost
+	}
+	if requested.MaxDurationSeconds > 0 {
+		budget.MaxDurationSeconds = requested.MaxDurationSeconds
+	}
+	return budget
+}
+
+// budgetTracker enforces a benchmark budget. Limits are checked before each item, so a
+// benchmark can overrun its token or cost limit by at most one item.
+type budgetTracker struct {
+	limits models.BenchmarkBudget
+	start  time.Time
+	items  int
+	usage  models.TokenUsage
+}
+
+func newBudgetTracker(limits models.BenchmarkBudget) *budgetTracker {
+	return &budgetTracker{limits: limits, start: time.Now()}
+}
+
+// record counts an evaluated item against the budget
+func (t *budgetTracker) record(eval models.EvaluationResult) {
+	t.items++
+	if eval.Usage != nil {
+		addUsage(&t.usage, eval.Usage.Judging)
+		addUsage(&t.usage, eval.Usage.Embedding)
+	}
+}
+
+// exceeded describes the first limit that has been reached, or returns "" if there is budget left
+func (t *budgetTracker) exceeded() string {
+	switch {
+	case t.limits.MaxItems > 0 && t.items >= t.limits.MaxItems:
+		return fmt.Sprintf("max items: %d of %d evaluated", t.items, t.limits.MaxItems)
+	case t.limits.MaxTokens > 0 && t.usage.TotalTokens >= t.limits.MaxTokens:
+		return fmt.Sprintf("max tokens: %d of %d used", t.usage.TotalTokens, t.limits.MaxTokens)
+	case t.limits.MaxCost > 0 && t.usage.EstimatedCost >= t.limits.MaxCost:
+		return fmt.Sprintf("max cost: $%.4f of $%.4f spent", t.usage.EstimatedCost, t.limits.MaxCost)
+	case t.limits.MaxDurationSeconds > 0 && time.Since(t.start) >= time.Duration(t.limits.MaxDurationSeconds)*time.Second:
+		return fmt.Sprintf("max duration: %ds elapsed", t.limits.MaxDurationSeconds)
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package benchmark
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestEffectiveBudget(t *testing.T) {
+	bs := &BenchmarkService{defaultBudget: models.BenchmarkBudget{MaxItems: 100, MaxCost: 5}}
+
+	got := bs.effectiveBudget(&models.BenchmarkBudget{MaxCost: 1, MaxTokens: 5000})
+	want := models.BenchmarkBudget{MaxItems: 100, MaxTokens: 5000, MaxCost: 1}
+	if got != want {
+		t.Errorf("effectiveBudget() = %+v, want %+v", got, want)
+	}
+	if got := bs.effectiveBudget(nil); got != bs.defaultBudget {
+		t.Errorf("effectiveBudget(nil) = %+v, want defaults", got)
+	}
+}
+
+func TestBudgetTracker(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits models.BenchmarkBudget
+		items  int
+		want   string
+	}{
+		{name: "unlimited", limits: models.BenchmarkBudget{}, items: 10, want: ""},
+		{name: "items", limits: models.BenchmarkBudget{MaxItems: 2}, items: 2, want: "max items"},
+		{name: "under items", limits: models.BenchmarkBudget{MaxItems: 3}, items: 2, want: ""},
+		{name: "tokens", limits: models.BenchmarkBudget{MaxTokens: 250}, items: 3, want: "max tokens"},
+		{name: "cost", limits: models.BenchmarkBudget{MaxCost: 0.02}, items: 2, want: "max cost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newBudgetTracker(tt.limits)
+			for i := 0; i < tt.items; i++ {
+				tracker.record(models.EvaluationResult{Usage: &models.ItemUsage{
+					Judging: models.TokenUsage{Requests: 1, TotalTokens: 100, EstimatedCost: 0.01},
+				}})
+			}
+			got := tracker.exceeded()
+			if (tt.want == "" && got != "") || !strings.HasPrefix(got, tt.want) {
+				t.Errorf("exceeded() = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package benchmark
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// judgeCache stores judge responses by a hash of everything that determines them,
+// so re-benchmarking a run only pays for items whose prompt actually changed
+type judgeCache struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// judgeCacheKey hashes the judge model, rubric version, prompts and sampling parameters.
+// The sample index is included so self-consistency samples are cached separately.
+func judgeCacheKey(model, systemPrompt, evaluationInput string, temperature float64, sample int) string {
+	parts := []string{
+		model,
+		evaluationRubricVersion,
+		systemPrompt,
+		evaluationInput,
+		strconv.FormatFloat(temperature, 'g', -1, 64),
+		strconv.Itoa(sample),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached response for key, counting the lookup as a hit or miss
+func (c *judgeCache) get(key string) (string, bool) {
+	response, err := storage.GetJudgeResponse(key)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			log.Printf("Error reading judge cache: %v", err)
+		}
+		c.misses.Add(1)
+		return "", false
+	}
+	c.hits.Add(1)
+	return response, true
+}
+
+// put stores a response that parsed successfully
+func (c *judgeCache) put(key, response string) {
+	if err := storage.SaveJudgeResponse(key, response); err != nil {
+		log.Printf("Error writing judge cache: %v", err)
+	}
+}
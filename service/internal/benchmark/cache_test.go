@@ -0,0 +1,25 @@
+package benchmark
+
+import "testing"
+
+func TestJudgeCacheKey(t *testing.T) {
+	base := judgeCacheKey("gpt-4o", "system", "input", 0, 0)
+	if base != judgeCacheKey("gpt-4o", "system", "input", 0, 0) {
+		t.Fatal("expected identical inputs to produce the same key")
+	}
+
+	variants := map[string]string{
+		"model":       judgeCacheKey("gpt-4o-mini", "system", "input", 0, 0),
+		"system":      judgeCacheKey("gpt-4o", "system2", "input", 0, 0),
+		"input":       judgeCacheKey("gpt-4o", "system", "input2", 0, 0),
+		"temperature": judgeCacheKey("gpt-4o", "system", "input", 0.7, 0),
+		"sample":      judgeCacheKey("gpt-4o", "system", "input", 0, 1),
+		// The separator stops fields from running into each other
+		"boundary": judgeCacheKey("gpt-4o", "systemi", "nput", 0, 0),
+	}
+	for name, key := range variants {
+		if key == base {
+			t.Errorf("changing %s did not change the key", name)
+		}
+	}
+}
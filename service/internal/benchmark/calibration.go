@@ -0,0 +1,265 @@
+package benchmark
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// maxDisagreements caps the disagreements listed per judge
+const maxDisagreements = 10
+
+// calibrationKey identifies a judge and the rubric it was given
+type calibrationKey struct {
+	judge  string
+	model  string
+	rubric string
+}
+
+// calibrationTally accumulates the comparisons for one judge and rubric
+type calibrationTally struct {
+	matrix         [][]int
+	relevancePairs int
+	relevanceAgree int
+	disagreements  []models.CalibrationDisagreement
+}
+
+// Calibration compares stored human annotations with the verdicts of each judge and rubric,
+// optionally restricted to one judge name and rubric version
+func (bs *BenchmarkService) Calibration(judge, rubricVersion string) (*models.CalibrationReport, error) {
+	annotations, err := storage.ListAnnotations("")
+	if err != nil {
+		return nil, err
+	}
+	benchmarks, err := storage.ListBenchmarkResults()
+	if err != nil {
+		return nil, err
+	}
+	return calibrate(benchmarks, annotations, judge, rubricVersion), nil
+}
+
+// calibrate pairs every annotation with each judge's verdict on the same item. When a run was
+// benchmarked more than once, only the latest verdict of each judge and rubric on an item counts.
+func calibrate(benchmarks []models.BenchmarkResults, annotations []models.Annotation, judgeFilter, rubricFilter string) *models.CalibrationReport {
+	byItem := make(map[string][]models.Annotation)
+	for _, annotation := range annotations {
+		key := annotation.RunID + "/" + annotation.ItemID
+		byItem[key] = append(byItem[key], annotation)
+	}
+
+	newestFirst := make([]models.BenchmarkResults, len(benchmarks))
+	copy(newestFirst, benchmarks)
+	sort.SliceStable(newestFirst, func(i, j int) bool {
+		return newestFirst[i].Timestamp.After(newestFirst[j].Timestamp)
+	})
+
+	tallies := make(map[calibrationKey]*calibrationTally)
+	counted := make(map[calibrationKey]map[string]bool)
+	for _, results := range newestFirst {
+		if results.FailureReason != "" {
+			continue
+		}
+		rubric := ""
+		if results.Judging != nil {
+			rubric = results.Judging.RubricVersion
+		}
+		if rubricFilter != "" && rubric != rubricFilter {
+			continue
+		}
+
+		for itemID, eval := range results.DetailedEvaluations {
+			itemAnnotations := byItem[results.RunID+"/"+itemID]
+			if len(itemAnnotations) == 0 || (eval.Status != "" && eval.Status != models.ItemStatusEvaluated) {
+				continue
+			}
+
+			for _, verdict := range itemVerdicts(results, eval) {
+				if verdict.Error != "" || (judgeFilter != "" && verdict.Judge != judgeFilter) {
+					continue
+				}
+				key := calibrationKey{judge: verdict.Judge, model: verdict.Model, rubric: rubric}
+				item := results.RunID + "/" + itemID
+				if counted[key][item] {
+					continue
+				}
+				tally, ok := tallies[key]
+				if !ok {
+					tally = &calibrationTally{matrix: make([][]int, len(qualityRatings))}
+					for i := range tally.matrix {
+						tally.matrix[i] = make([]int, len(qualityRatings))
+					}
+					tallies[key] = tally
+					counted[key] = make(map[string]bool)
+				}
+				counted[key][item] = true
+				for _, annotation := range itemAnnotations {
+					tally.add(results.BenchmarkID, verdict, annotation)
+				}
+			}
+		}
+	}
+
+	report := &models.CalibrationReport{
+		GeneratedAt: time.Now(),
+		Annotations: len(annotations),
+		Judges:      []models.JudgeCalibration{},
+	}
+	for key, tally := range tallies {
+		report.Judges = append(report.Judges, tally.summarise(key))
+	}
+	sort.Slice(report.Judges, func(i, j int) bool {
+		a, b := report.Judges[i], report.Judges[j]
+		if a.Judge != b.Judge {
+			return a.Judge < b.Judge
+		}
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		return a.RubricVersion < b.RubricVersion
+	})
+	return report
+}
+
+// itemVerdicts returns each judge's verdict on an item. Single-judge benchmarks only store the
+// combined verdict, which is then attributed to that judge.
+func itemVerdicts(results models.BenchmarkResults, eval models.EvaluationResult) []models.JudgeVerdict {
+	if len(eval.JudgeVerdicts) > 0 {
+		return eval.JudgeVerdicts
+	}
+
+	verdict := models.JudgeVerdict{
+		QualityRating:      eval.QualityRating,
+		QualityExplanation: eval.QualityExplanation,
+		RelevanceCorrect:   eval.RelevanceCorrect,
+	}
+	if results.Judging != nil && len(results.Judging.Judges) == 1 {
+		verdict.Judge = results.Judging.Judges[0].Name
+		verdict.Model = results.Judging.Judges[0].Model
+	}
+	return []models.JudgeVerdict{verdict}
+}
+
+// add records one comparison of a judge verdict with a human annotation
+func (t *calibrationTally) add(benchmarkID string, verdict models.JudgeVerdict, annotation models.Annotation) {
+	disagreement := models.CalibrationDisagreement{
+		RunID:                 annotation.RunID,
+		ItemID:                annotation.ItemID,
+		BenchmarkID:           benchmarkID,
+		AnnotationID:          annotation.ID,
+		Annotator:             annotation.Annotator,
+		HumanRating:           annotation.QualityRating,
+		JudgeRating:           verdict.QualityRating,
+		HumanRelevanceCorrect: annotation.RelevanceCorrect,
+		JudgeRelevanceCorrect: verdict.RelevanceCorrect,
+		Notes:                 annotation.Notes,
+		JudgeExplanation:      verdict.QualityExplanation,
+	}
+
+	human, judge := ratingIndex(annotation.QualityRating), ratingIndex(verdict.QualityRating)
+	if human >= 0 && judge >= 0 {
+		t.matrix[human][judge]++
+		disagreement.RatingDistance = max(human-judge, judge-human)
+	}
+	if annotation.RelevanceCorrect != nil {
+		t.relevancePairs++
+		if *annotation.RelevanceCorrect == verdict.RelevanceCorrect {
+			t.relevanceAgree++
+		} else {
+			disagreement.RelevanceDisagrees = true
+		}
+	}
+
+	if disagreement.RatingDistance > 0 || disagreement.RelevanceDisagrees {
+		t.disagreements = append(t.disagreements, disagreement)
+	}
+}
+
+// summarise computes agreement statistics and the largest disagreements
+func (t *calibrationTally) summarise(key calibrationKey) models.JudgeCalibration {
+	calibration := models.JudgeCalibration{
+		Judge:           key.judge,
+		Model:           key.model,
+		RubricVersion:   key.rubric,
+		Ratings:         qualityRatings,
+		ConfusionMatrix: t.matrix,
+		CohensKappa:     cohensKappa(t.matrix),
+		RelevancePairs:  t.relevancePairs,
+	}
+
+	agreeing := 0
+	for i, row := range t.matrix {
+		for _, count := range row {
+			calibration.RatedPairs += count
+		}
+		agreeing += row[i]
+	}
+	if calibration.RatedPairs > 0 {
+		calibration.ExactAgreement = float64(agreeing) / float64(calibration.RatedPairs)
+	}
+	if t.relevancePairs > 0 {
+		calibration.RelevanceAgreement = float64(t.relevanceAgree) / float64(t.relevancePairs)
+	}
+
+	// Rating distance outweighs a relevance disagreement; ties are broken for a stable order
+	disagreements := t.disagreements
+	sort.Slice(disagreements, func(i, j int) bool {
+		a, b := disagreements[i], disagreements[j]
+		if a.RatingDistance != b.RatingDistance {
+			return a.RatingDistance > b.RatingDistance
+		}
+		if a.RelevanceDisagrees != b.RelevanceDisagrees {
+			return a.RelevanceDisagrees
+		}
+		if a.RunID != b.RunID {
+			return a.RunID < b.RunID
+		}
+		if a.ItemID != b.ItemID {
+			return a.ItemID < b.ItemID
+		}
+		if a.BenchmarkID != b.BenchmarkID {
+			return a.BenchmarkID < b.BenchmarkID
+		}
+		return a.AnnotationID < b.AnnotationID
+	})
+	if len(disagreements) > maxDisagreements {
+		disagreements = disagreements[:maxDisagreements]
+	}
+	calibration.Disagreements = disagreements
+	return calibration
+}
+
+// cohensKappa computes Cohen's kappa for a square confusion matrix of two raters. When chance
+// agreement is total, as when both raters only ever use one rating, perfect agreement yields 1.
+func cohensKappa(matrix [][]int) float64 {
+	total := 0
+	rows := make([]int, len(matrix))
+	cols := make([]int, len(matrix))
+	observed := 0
+	for i, row := range matrix {
+		for j, count := range row {
+			total += count
+			rows[i] += count
+			cols[j] += count
+		}
+		observed += row[i]
+	}
+	if total == 0 {
+		return 0
+	}
+
+	n := float64(total)
+	po := float64(observed) / n
+	var pe float64
+	for i := range rows {
+		pe += float64(rows[i]) * float64(cols[i]) / (n * n)
+	}
+	if pe == 1 {
+		if po == 1 {
+			return 1
+		}
+		return 0
+	}
+	return (po - pe) / (1 - pe)
+}
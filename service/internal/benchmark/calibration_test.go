@@ -0,0 +1,118 @@
+package benchmark
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestCohensKappa(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]int
+		want   float64
+	}{
+		{name: "empty", matrix: [][]int{{0, 0}, {0, 0}}, want: 0},
+		{name: "perfect", matrix: [][]int{{5, 0}, {0, 5}}, want: 1},
+		{name: "single category", matrix: [][]int{{4, 0}, {0, 0}}, want: 1},
+		{name: "chance", matrix: [][]int{{25, 25}, {25, 25}}, want: 0},
+		// po = 0.7, pe = 0.5 * 0.6 + 0.5 * 0.4 = 0.5
+		{name: "textbook", matrix: [][]int{{20, 5}, {10, 15}}, want: 0.4},
+		{name: "systematic disagreement", matrix: [][]int{{0, 5}, {5, 0}}, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cohensKappa(tt.matrix); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cohensKappa() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	yes, no := true, false
+	verdict := func(judge, rating string, relevanceCorrect bool) models.JudgeVerdict {
+		return models.JudgeVerdict{Judge: judge, Model: judge + "-model", QualityRating: rating, RelevanceCorrect: relevanceCorrect}
+	}
+	benchmarks := []models.BenchmarkResults{
+		{
+			BenchmarkID: "panel",
+			RunID:       "run",
+			Judging:     &models.JudgeSettings{RubricVersion: "1"},
+			DetailedEvaluations: map[string]models.EvaluationResult{
+				"a": {Status: models.ItemStatusEvaluated, JudgeVerdicts: []models.JudgeVerdict{verdict("x", "Good", true), verdict("y", "Poor", true)}},
+				"b": {Status: models.ItemStatusEvaluated, JudgeVerdicts: []models.JudgeVerdict{verdict("x", "Fair", false), verdict("y", "Fair", true)}},
+				"c": {Status: models.ItemStatusJudgeError},
+			},
+		},
+		{
+			BenchmarkID: "single",
+			RunID:       "run",
+			Judging:     &models.JudgeSettings{RubricVersion: "2", Judges: []models.JudgeInfo{{Name: "x", Model: "x-model"}}},
+			DetailedEvaluations: map[string]models.EvaluationResult{
+				"a": {Status: models.ItemStatusEvaluated, QualityRating: "Excellent", RelevanceCorrect: true},
+			},
+		},
+		{BenchmarkID: "failed", RunID: "run", FailureReason: "boom"},
+		// An earlier benchmark of the same run is superseded by "panel"
+		{
+			BenchmarkID: "earlier",
+			RunID:       "run",
+			Timestamp:   time.Now().Add(-time.Hour),
+			Judging:     &models.JudgeSettings{RubricVersion: "1"},
+			DetailedEvaluations: map[string]models.EvaluationResult{
+				"a": {Status: models.ItemStatusEvaluated, JudgeVerdicts: []models.JudgeVerdict{verdict("x", "Poor", false)}},
+			},
+		},
+	}
+	benchmarks[0].Timestamp = time.Now()
+	annotations := []models.Annotation{
+		{ID: "1", RunID: "run", ItemID: "a", Annotator: "sam", QualityRating: "Good", RelevanceCorrect: &yes},
+		{ID: "2", RunID: "run", ItemID: "b", Annotator: "sam", QualityRating: "Fair", RelevanceCorrect: &no},
+		{ID: "3", RunID: "run", ItemID: "c", Annotator: "sam", QualityRating: "Poor"},
+		{ID: "4", RunID: "other", ItemID: "a", Annotator: "sam", QualityRating: "Poor"},
+	}
+
+	report := calibrate(benchmarks, annotations, "", "")
+
+	if report.Annotations != 4 {
+		t.Errorf("Annotations = %d, want 4", report.Annotations)
+	}
+	if len(report.Judges) != 3 {
+		t.Fatalf("Judges = %+v, want x under rubrics 1 and 2, and y", report.Judges)
+	}
+
+	x1, x2, y := report.Judges[0], report.Judges[1], report.Judges[2]
+	if x1.Judge != "x" || x1.RubricVersion != "1" || x2.RubricVersion != "2" || y.Judge != "y" {
+		t.Fatalf("judge order = %s/%s, %s/%s, %s/%s", x1.Judge, x1.RubricVersion, x2.Judge, x2.RubricVersion, y.Judge, y.RubricVersion)
+	}
+
+	// Judge x agreed with the annotator on both items
+	good, fair := ratingIndex("Good"), ratingIndex("Fair")
+	if x1.RatedPairs != 2 || x1.ConfusionMatrix[good][good] != 1 || x1.ConfusionMatrix[fair][fair] != 1 {
+		t.Errorf("x rubric 1 matrix = %v, want Good/Good and Fair/Fair", x1.ConfusionMatrix)
+	}
+	if x1.ExactAgreement != 1 || x1.CohensKappa != 1 || x1.RelevanceAgreement != 1 || len(x1.Disagreements) != 0 {
+		t.Errorf("x rubric 1 = %+v, want full agreement", x1)
+	}
+
+	// The single-judge benchmark is attributed to its only judge
+	if x2.Model != "x-model" || x2.RatedPairs != 1 || x2.Disagreements[0].RatingDistance != 1 {
+		t.Errorf("x rubric 2 = %+v, want one pair a step apart", x2)
+	}
+
+	// Judge y rated a two steps from the annotator and disagreed on b's relevance
+	if y.ExactAgreement != 0.5 || y.RelevanceAgreement != 0.5 || len(y.Disagreements) != 2 {
+		t.Fatalf("y = %+v, want half agreement and two disagreements", y)
+	}
+	if y.Disagreements[0].ItemID != "a" || y.Disagreements[0].RatingDistance != 2 || !y.Disagreements[1].RelevanceDisagrees {
+		t.Errorf("y disagreements = %+v, want a (distance 2) before b (relevance)", y.Disagreements)
+	}
+
+	filtered := calibrate(benchmarks, annotations, "x", "2")
+	if len(filtered.Judges) != 1 || filtered.Judges[0].RubricVersion != "2" {
+		t.Errorf("filtered judges = %+v, want only x under rubric 2", filtered.Judges)
+	}
+}
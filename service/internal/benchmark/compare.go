@@ -0,0 +1,132 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// ErrNotComparable is returned when a benchmark has no scores to compare
+var ErrNotComparable = errors.New("benchmark cannot be compared")
+
+// loadComparable loads the two benchmarks of a comparison, rejecting any that failed
+func loadComparable(baseBenchmarkID, headBenchmarkID string) (base, head *models.BenchmarkResults, err error) {
+	base, err = storage.GetBenchmarkResultsByBenchmarkID(baseBenchmarkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base benchmark: %w", err)
+	}
+	head, err = storage.GetBenchmarkResultsByBenchmarkID(headBenchmarkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get head benchmark: %w", err)
+	}
+
+	for _, results := range []*models.BenchmarkResults{base, head} {
+		if results.FailureReason != "" {
+			return nil, nil, fmt.Errorf("%w: benchmark %s failed: %s", ErrNotComparable, results.BenchmarkID, results.FailureReason)
+		}
+	}
+	return base, head, nil
+}
+
+// CompareSignificance tests whether the scores of two benchmarks differ by more than chance
+func (bs *BenchmarkService) CompareSignificance(baseBenchmarkID, headBenchmarkID string) (*models.BenchmarkSignificance, error) {
+	base, head, err := loadComparable(baseBenchmarkID, headBenchmarkID)
+	if err != nil {
+		return nil, err
+	}
+	return compareSignificance(base, head), nil
+}
+
+// CompareBenchmarks aligns the items of two benchmarks by ID and reports what changed from base to head
+func (bs *BenchmarkService) CompareBenchmarks(baseBenchmarkID, headBenchmarkID string) (*models.BenchmarkComparison, error) {
+	base, head, err := loadComparable(baseBenchmarkID, headBenchmarkID)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := diffBenchmarks(base, head)
+	comparison.Significance = compareSignificance(base, head)
+	return comparison, nil
+}
+
+// diffBenchmarks compares the aggregates and per-item verdicts of two benchmarks
+func diffBenchmarks(base, head *models.BenchmarkResults) *models.BenchmarkComparison {
+	comparison := &models.BenchmarkComparison{
+		BaseBenchmarkID:        base.BenchmarkID,
+		HeadBenchmarkID:        head.BenchmarkID,
+		BaseRunID:              base.RunID,
+		HeadRunID:              head.RunID,
+		QualityScoreDelta:      head.QualityScore - base.QualityScore,
+		RelevanceAccuracyDelta: head.RelevanceAccuracy - base.RelevanceAccuracy,
+		CoverageDelta:          head.Coverage - base.Coverage,
+	}
+
+	for id, baseEval := range base.DetailedEvaluations {
+		headEval, ok := head.DetailedEvaluations[id]
+		if !ok {
+			comparison.OnlyInBase = append(comparison.OnlyInBase, id)
+			continue
+		}
+		if !isScored(baseEval) || !isScored(headEval) {
+			comparison.NotCompared = append(comparison.NotCompared, id)
+			continue
+		}
+		comparison.ComparedItems++
+
+		change := models.RatingChange{
+			ItemID:     id,
+			BaseRating: baseEval.QualityRating,
+			HeadRating: headEval.QualityRating,
+			ScoreDelta: ratingScore(headEval.QualityRating) - ratingScore(baseEval.QualityRating),
+		}
+		switch {
+		case change.ScoreDelta > 0:
+			comparison.Improved = append(comparison.Improved, change)
+		case change.ScoreDelta < 0:
+			comparison.Regressed = append(comparison.Regressed, change)
+		default:
+			comparison.UnchangedItems++
+		}
+
+		if baseEval.RelevanceCorrect != headEval.RelevanceCorrect {
+			relevance := models.RelevanceChange{ItemID: id, BaseCorrect: baseEval.RelevanceCorrect, HeadCorrect: headEval.RelevanceCorrect}
+			if headEval.RelevanceCorrect {
+				comparison.RelevanceFixed = append(comparison.RelevanceFixed, relevance)
+			} else {
+				comparison.RelevanceBroken = append(comparison.RelevanceBroken, relevance)
+			}
+		}
+	}
+	for id := range head.DetailedEvaluations {
+		if _, ok := base.DetailedEvaluations[id]; !ok {
+			comparison.OnlyInHead = append(comparison.OnlyInHead, id)
+		}
+	}
+
+	// Largest changes first, then by ID so the output is stable
+	sortChanges := func(changes []models.RatingChange) {
+		sort.Slice(changes, func(i, j int) bool {
+			di, dj := math.Abs(changes[i].ScoreDelta), math.Abs(changes[j].ScoreDelta)
+			if di != dj {
+				return di > dj
+			}
+			return changes[i].ItemID < changes[j].ItemID
+		})
+	}
+	sortChanges(comparison.Improved)
+	sortChanges(comparison.Regressed)
+	sortRelevance := func(changes []models.RelevanceChange) {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].ItemID < changes[j].ItemID })
+	}
+	sortRelevance(comparison.RelevanceFixed)
+	sortRelevance(comparison.RelevanceBroken)
+	sort.Strings(comparison.OnlyInBase)
+	sort.Strings(comparison.OnlyInHead)
+	sort.Strings(comparison.NotCompared)
+
+	return comparison
+}
@@ -0,0 +1,65 @@
+package benchmark
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestDiffBenchmarks(t *testing.T) {
+	verdict := func(rating string, relevanceCorrect bool) models.EvaluationResult {
+		return models.EvaluationResult{QualityRating: rating, RelevanceCorrect: relevanceCorrect, Status: models.ItemStatusEvaluated}
+	}
+	base := &models.BenchmarkResults{
+		BenchmarkID:       "base",
+		QualityScore:      60,
+		RelevanceAccuracy: 0.5,
+		DetailedEvaluations: map[string]models.EvaluationResult{
+			"same":     verdict("Good", true),
+			"up":       verdict("Poor", false),
+			"down":     verdict("Excellent", true),
+			"errored":  verdict("Good", true),
+			"old-only": verdict("Fair", true),
+		},
+	}
+	head := &models.BenchmarkResults{
+		BenchmarkID:       "head",
+		QualityScore:      70,
+		RelevanceAccuracy: 0.75,
+		DetailedEvaluations: map[string]models.EvaluationResult{
+			"same":     verdict("Good", true),
+			"up":       verdict("Good", true),
+			"down":     verdict("Fair", false),
+			"errored":  {Status: models.ItemStatusJudgeError},
+			"new-only": verdict("Fair", true),
+		},
+	}
+
+	got := diffBenchmarks(base, head)
+
+	if got.QualityScoreDelta != 10 || got.RelevanceAccuracyDelta != 0.25 {
+		t.Errorf("deltas = %.1f, %.2f, want 10, 0.25", got.QualityScoreDelta, got.RelevanceAccuracyDelta)
+	}
+	if got.ComparedItems != 3 || got.UnchangedItems != 1 {
+		t.Errorf("ComparedItems = %d, UnchangedItems = %d, want 3 and 1", got.ComparedItems, got.UnchangedItems)
+	}
+	if len(got.Improved) != 1 || got.Improved[0].ItemID != "up" || got.Improved[0].ScoreDelta <= 0 {
+		t.Errorf("Improved = %+v, want only \"up\"", got.Improved)
+	}
+	if len(got.Regressed) != 1 || got.Regressed[0].ItemID != "down" || got.Regressed[0].ScoreDelta >= 0 {
+		t.Errorf("Regressed = %+v, want only \"down\"", got.Regressed)
+	}
+	if len(got.RelevanceFixed) != 1 || got.RelevanceFixed[0].ItemID != "up" {
+		t.Errorf("RelevanceFixed = %+v, want only \"up\"", got.RelevanceFixed)
+	}
+	if len(got.RelevanceBroken) != 1 || got.RelevanceBroken[0].ItemID != "down" {
+		t.Errorf("RelevanceBroken = %+v, want only \"down\"", got.RelevanceBroken)
+	}
+	if !reflect.DeepEqual(got.OnlyInBase, []string{"old-only"}) || !reflect.DeepEqual(got.OnlyInHead, []string{"new-only"}) {
+		t.Errorf("OnlyInBase = %v, OnlyInHead = %v", got.OnlyInBase, got.OnlyInHead)
+	}
+	if !reflect.DeepEqual(got.NotCompared, []string{"errored"}) {
+		t.Errorf("NotCompared = %v, want [errored]", got.NotCompared)
+	}
+}
@@ -0,0 +1,428 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/openai"
+)
+
+const (
+	// AggregationMajority picks the most common rating across judges
+	AggregationMajority = "majority"
+	// AggregationMean averages the judges' numeric scores and maps back to the nearest rating
+	AggregationMean = "mean"
+)
+
+var (
+	// errJudgeCall means the request to the judge failed
+	errJudgeCall = errors.New("judge call failed")
+	// errJudgeResponse means the judge responded but the verdict could not be used
+	errJudgeResponse = errors.New("could not parse judge response")
+)
+
+// qualityRatings lists the quality ratings from worst to best
+var qualityRatings = []string{"Poor", "Fair", "Good", "Excellent"}
+
+// ratingScore converts a quality rating into its numeric score, with Poor rated at 0%
+func ratingScore(rating string) float64 {
+	switch rating {
+	case "Excellent":
+		return 100.0
+	case "Good":
+		return 75.0
+	case "Fair":
+		return 50.0
+	default:
+		return 0.0
+	}
+}
+
+// ratingForScore maps a numeric score back to the nearest quality rating
+func ratingForScore(score float64) string {
+	switch {
+	case score >= 87.5:
+		return "Excellent"
+	case score >= 62.5:
+		return "Good"
+	case score >= 25.0:
+		return "Fair"
+	default:
+		return "Poor"
+	}
+}
+
+// ratingIndex returns the position of a rating in qualityRatings, or -1 if unknown
+func ratingIndex(rating string) int {
+	for i, r := range qualityRatings {
+		if r == rating {
+			return i
+		}
+	}
+	return -1
+}
+
+// judge pairs a judge's configuration with its LLM client
+type judge struct {
+	config internal.JudgeConfig
+	client openai.OpenAIClient
+}
+
+// newJudgePanel creates an LLM client for every configured judge
+func (bs *BenchmarkService) newJudgePanel() []judge {
+	panel := make([]judge, 0, len(bs.judges))
+	for _, cfg := range bs.judges {
+		panel = append(panel, judge{
+			config: cfg,
+			client: openai.New(cfg.URL, cfg.APIKey, cfg.Model),
+		})
+	}
+	return panel
+}
+
+// judgeSettings describes the panel in a form that is safe to persist
+func (bs *BenchmarkService) judgeSettings() *models.JudgeSettings {
+	settings := &models.JudgeSettings{
+		Judges:        make([]models.JudgeInfo, 0, len(bs.judges)),
+		Aggregation:   bs.aggregation,
+		Samples:       bs.samples,
+		RubricVersion: evaluationRubricVersion,
+	}
+	if bs.samples > 1 {
+		settings.Temperature = bs.sampleTemperature
+	}
+	for _, cfg := range bs.judges {
+		settings.Judges = append(settings.Judges, models.JudgeInfo{
+			Name:  cfg.Name,
+			Model: cfg.Model,
+			URL:   cfg.URL,
+		})
+	}
+	return settings
+}
+
+// evaluateWithPanel asks every judge on the panel to evaluate the input.
+// A nil cache sends every request to the judges.
+func (bs *BenchmarkService) evaluateWithPanel(panel []judge, cache *judgeCache, systemPrompt, evaluationInput string) []models.JudgeVerdict {
+	verdicts := make([]models.JudgeVerdict, 0, len(panel))
+	for _, j := range panel {
+		verdicts = append(verdicts, bs.evaluateWithJudge(j, cache, systemPrompt, evaluationInput))
+	}
+	return verdicts
+}
+
+// evaluateWithJudge queries a single judge, sampling it several times when
+// self-consistency is enabled, and returns its modal verdict
+func (bs *BenchmarkService) evaluateWithJudge(j judge, cache *judgeCache, systemPrompt, evaluationInput string) models.JudgeVerdict {
+	verdict := models.JudgeVerdict{
+		Judge: j.config.Name,
+		Model: j.config.Model,
+	}
+
+	temperature := 0.0
+	if bs.samples > 1 {
+		temperature = bs.sampleTemperature
+	}
+
+	// Every judge is asked at least once, whatever the configured sample count
+	sampleCount := max(bs.samples, 1)
+
+	var samples []EvaluationResult
+	var lastErr error
+	var usage models.TokenUsage
+	for i := 0; i < sampleCount; i++ {
+		sample, sampleUsage, err := bs.requestVerdict(j, cache, systemPrompt, evaluationInput, temperature, i)
+		addUsage(&usage, sampleUsage)
+		if err != nil {
+			log.Printf("Judge %s sample %d/%d failed: %v", j.config.Name, i+1, sampleCount, err)
+			lastErr = err
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	verdict.Usage = &usage
+	if len(samples) == 0 {
+		verdict.Error = lastErr.Error()
+		verdict.Status = models.ItemStatusJudgeError
+		if errors.Is(lastErr, errJudgeResponse) {
+			verdict.Status = models.ItemStatusParseError
+		}
+		return verdict
+	}
+
+	ratings := make([]string, 0, len(samples))
+	relevantVotes := 0
+	for _, sample := range samples {
+		ratings = append(ratings, sample.QualityRating)
+		if sample.RelevanceCorrect {
+			relevantVotes++
+		}
+	}
+	verdict.QualityRating = modalRating(ratings)
+	verdict.RelevanceCorrect = relevantVotes*2 > len(samples)
+
+	for _, sample := range samples {
+		if sample.QualityRating == verdict.QualityRating && verdict.QualityExplanation == "" {
+			verdict.QualityExplanation = sample.QualityExplanation
+		}
+		if sample.RelevanceCorrect == verdict.RelevanceCorrect && verdict.RelevanceExplanation == "" {
+			verdict.RelevanceExplanation = sample.RelevanceExplanation
+		}
+	}
+
+	if bs.samples > 1 {
+		verdict.SampleRatings = ratings
+	}
+	return verdict
+}
+
+// requestVerdict performs a single judge call and parses the response, returning the usage of the call.
+// Responses are served from and saved to the cache when one is given; cached responses cost nothing.
+func (bs *BenchmarkService) requestVerdict(j judge, cache *judgeCache, systemPrompt, evaluationInput string, temperature float64, sample int) (EvaluationResult, models.TokenUsage, error) {
+	var evalResult EvaluationResult
+	var usage models.TokenUsage
+
+	var cacheKey, jsonStr string
+	cached := false
+	if cache != nil {
+		cacheKey = judgeCacheKey(j.config.Model, systemPrompt, evaluationInput, temperature, sample)
+		jsonStr, cached = cache.get(cacheKey)
+	}
+
+	if !cached {
+		resultChan := make(chan openai.CompletionResult, 1)
+		bs.chatCompletionForBenchmarkEvaluation(j.client, systemPrompt, []string{evaluationInput}, temperature, resultChan)
+		evalResponse := <-resultChan
+		usage = bs.tokenUsage(j.config.Model, evalResponse.Usage, evalResponse.Latency)
+		if evalResponse.Err != nil {
+			return evalResult, usage, fmt.Errorf("%w: %w", errJudgeCall, evalResponse.Err)
+		}
+		jsonStr = j.client.PreprocessJSON(evalResponse.Value)
+	}
+
+	if err := json.Unmarshal([]byte(jsonStr), &evalResult); err != nil {
+		return evalResult, usage, fmt.Errorf("%w: %w", errJudgeResponse, err)
+	}
+	if ratingIndex(evalResult.QualityRating) == -1 {
+		return evalResult, usage, fmt.Errorf("%w: unknown quality rating %q", errJudgeResponse, evalResult.QualityRating)
+	}
+
+	// Only usable responses are cached, so a bad response is retried next time
+	if cache != nil && !cached {
+		cache.put(cacheKey, jsonStr)
+	}
+	return evalResult, usage, nil
+}
+
+// combineVerdicts merges the panel's verdicts into a single evaluation.
+// It returns false if no judge produced a usable verdict.
+func combineVerdicts(verdicts []models.JudgeVerdict, aggregation string) (models.EvaluationResult, bool) {
+	var successful []models.JudgeVerdict
+	for _, v := range verdicts {
+		if v.Error == "" {
+			successful = append(successful, v)
+		} else {
+			log.Printf("Judge %s failed: %s", v.Judge, v.Error)
+		}
+	}
+	if len(successful) == 0 {
+		return models.EvaluationResult{}, false
+	}
+
+	ratings := make([]string, 0, len(successful))
+	relevantVotes := 0
+	for _, v := range successful {
+		ratings = append(ratings, v.QualityRating)
+		if v.RelevanceCorrect {
+			relevantVotes++
+		}
+	}
+
+	var rating string
+	if aggregation == AggregationMean {
+		var total float64
+		for _, r := range ratings {
+			total += ratingScore(r)
+		}
+		rating = ratingForScore(total / float64(len(ratings)))
+	} else {
+		rating = modalRating(ratings)
+	}
+
+	// Ties on relevance resolve to incorrect, the conservative reading
+	relevanceCorrect := relevantVotes*2 > len(successful)
+
+	result := models.EvaluationResult{
+		QualityRating:    rating,
+		RelevanceCorrect: relevanceCorrect,
+	}
+
+	// Confidence is the share of every sample, across all judges, that backs the final rating
+	var sampleCount, sampleAgreeing int
+	agreeing := 0
+	for _, v := range successful {
+		for _, r := range verdictSamples(v) {
+			sampleCount++
+			if r == rating {
+				sampleAgreeing++
+			}
+		}
+		if v.QualityRating == rating {
+			agreeing++
+			if result.QualityExplanation == "" {
+				result.QualityExplanation = v.QualityExplanation
+			}
+		}
+		if v.RelevanceCorrect == relevanceCorrect && result.RelevanceExplanation == "" {
+			result.RelevanceExplanation = v.RelevanceExplanation
+		}
+	}
+	if result.QualityExplanation == "" {
+		// Mean aggregation can land on a rating no judge gave
+		result.QualityExplanation = successful[0].QualityExplanation
+	}
+
+	result.Confidence = float64(sampleAgreeing) / float64(sampleCount)
+
+	if len(verdicts) > 1 {
+		result.JudgeVerdicts = verdicts
+		result.Disagreement = 1 - float64(agreeing)/float64(len(successful))
+	}
+	return result, true
+}
+
+// failedEvaluation records why no judge produced a verdict. A parse error from any
+// judge takes precedence, since it points at the rubric rather than the network.
+func failedEvaluation(verdicts []models.JudgeVerdict) models.EvaluationResult {
+	result := models.EvaluationResult{
+		Status: models.ItemStatusJudgeError,
+		Error:  "no judges configured",
+	}
+	for i, v := range verdicts {
+		if i == 0 || v.Status == models.ItemStatusParseError && result.Status != models.ItemStatusParseError {
+			result.Status = v.Status
+			result.Error = fmt.Sprintf("%s: %s", v.Judge, v.Error)
+		}
+	}
+	if len(verdicts) > 1 {
+		result.JudgeVerdicts = verdicts
+	}
+	return result
+}
+
+// verdictSamples returns the individual ratings behind a judge's verdict
+func verdictSamples(v models.JudgeVerdict) []string {
+	if len(v.SampleRatings) > 0 {
+		return v.SampleRatings
+	}
+	return []string{v.QualityRating}
+}
+
+// modalRating returns the most common rating. Ties resolve to the lower rating.
+func modalRating(ratings []string) string {
+	counts := make(map[string]int)
+	for _, r := range ratings {
+		counts[r]++
+	}
+
+	best := ""
+	bestCount := 0
+	for _, r := range qualityRatings {
+		if counts[r] > bestCount {
+			best = r
+			bestCount = counts[r]
+		}
+	}
+	return best
+}
+
+// judgeAgreement computes Fleiss' kappa across the items every judge rated
+func judgeAgreement(evaluations map[string]models.EvaluationResult, judgeCount int) *models.JudgeAgreement {
+	agreement := &models.JudgeAgreement{}
+
+	var qualityCounts, relevanceCounts [][]int
+	for _, eval := range evaluations {
+		if len(eval.JudgeVerdicts) != judgeCount {
+			continue
+		}
+
+		quality := make([]int, len(qualityRatings))
+		relevance := make([]int, 2)
+		complete := true
+		for _, v := range eval.JudgeVerdicts {
+			if v.Error != "" {
+				complete = false
+				break
+			}
+			quality[ratingIndex(v.QualityRating)]++
+			if v.RelevanceCorrect {
+				relevance[1]++
+			} else {
+				relevance[0]++
+			}
+		}
+		if !complete {
+			continue
+		}
+
+		for _, c := range quality {
+			if c == judgeCount {
+				agreement.UnanimousItems++
+			}
+		}
+		qualityCounts = append(qualityCounts, quality)
+		relevanceCounts = append(relevanceCounts, relevance)
+	}
+
+	agreement.ItemsRated = len(qualityCounts)
+	agreement.QualityKappa = fleissKappa(qualityCounts)
+	agreement.RelevanceKappa = fleissKappa(relevanceCounts)
+	return agreement
+}
+
+// fleissKappa computes Fleiss' kappa for a matrix where counts[i][j] is the number
+// of raters that put item i into category j. Every item must have the same number
+// of raters. Perfect agreement with no variation across categories yields 1.
+func fleissKappa(counts [][]int) float64 {
+	if len(counts) == 0 {
+		return 0
+	}
+
+	raters := 0
+	for _, c := range counts[0] {
+		raters += c
+	}
+	if raters < 2 {
+		return 0
+	}
+
+	categoryTotals := make([]float64, len(counts[0]))
+	var observed float64
+	for _, row := range counts {
+		var agreeingPairs float64
+		for j, c := range row {
+			categoryTotals[j] += float64(c)
+			agreeingPairs += float64(c * (c - 1))
+		}
+		observed += agreeingPairs / float64(raters*(raters-1))
+	}
+	observed /= float64(len(counts))
+
+	totalRatings := float64(len(counts) * raters)
+	var expected float64
+	for _, total := range categoryTotals {
+		p := total / totalRatings
+		expected += p * p
+	}
+
+	if math.Abs(1-expected) < 1e-12 {
+		// Every rating fell into one category, so agreement is perfect
+		return 1
+	}
+	return (observed - expected) / (1 - expected)
+}
@@ -0,0 +1,201 @@
+package benchmark
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/http/retry"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/openai"
+)
+
+// fakeJudgeClient answers chat completions from respond, which sees the user prompts of each call,
+// and embeddings from embed
+type fakeJudgeClient struct {
+	respond func(userPrompts []string) (string, error)
+	embed   func(inputs []string) ([][]float64, error)
+	calls   int
+}
+
+func (f *fakeJudgeClient) ChatCompletion(systemPrompt string, userPrompts []string, imageURLs []string, schemaParams *openai.SchemaParameters, temperature float64, maxTokens int, results chan openai.CompletionResult) {
+	f.calls++
+	value, err := f.respond(userPrompts)
+	results <- openai.CompletionResult{Value: value, Usage: openai.Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, Err: err}
+}
+
+func (f *fakeJudgeClient) Embeddings(inputs []string) ([][]float64, openai.Usage, error) {
+	if f.embed == nil {
+		return nil, openai.Usage{}, errors.New("not supported")
+	}
+	embeddings, err := f.embed(inputs)
+	return embeddings, openai.Usage{PromptTokens: int64(len(inputs)), TotalTokens: int64(len(inputs))}, err
+}
+
+func (f *fakeJudgeClient) SetRetryConfig(config retry.RetryConfig) {}
+
+func (f *fakeJudgeClient) PreprocessYAML(response string) string { return response }
+
+func (f *fakeJudgeClient) PreprocessJSON(response string) string { return response }
+
+func (f *fakeJudgeClient) GetModelName() string { return "fake" }
+
+func TestFleissKappa(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   [][]int
+		expected float64
+	}{
+		{
+			name:     "no items",
+			counts:   nil,
+			expected: 0,
+		},
+		{
+			name:     "all raters agree on one category",
+			counts:   [][]int{{3, 0}, {3, 0}},
+			expected: 1,
+		},
+		{
+			name:     "perfect agreement across categories",
+			counts:   [][]int{{2, 0}, {0, 2}},
+			expected: 1,
+		},
+		{
+			name:     "complete disagreement",
+			counts:   [][]int{{1, 1}, {1, 1}},
+			expected: -1,
+		},
+		{
+			// Worked example from Fleiss (1971) reduced to two categories
+			name:     "partial agreement",
+			counts:   [][]int{{3, 0}, {2, 1}, {0, 3}, {1, 2}},
+			expected: 0.333,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := fleissKappa(tt.counts)
+			if math.Abs(result-tt.expected) > 0.001 {
+				t.Errorf("expected %.3f, got %.3f", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCombineVerdicts(t *testing.T) {
+	verdicts := []models.JudgeVerdict{
+		{Judge: "a", QualityRating: "Good", RelevanceCorrect: true},
+		{Judge: "b", QualityRating: "Good", RelevanceCorrect: false},
+		{Judge: "c", QualityRating: "Poor", RelevanceCorrect: true},
+		{Judge: "d", Error: "judge call failed"},
+	}
+
+	majority, ok := combineVerdicts(verdicts, AggregationMajority)
+	if !ok {
+		t.Fatal("expected a combined verdict")
+	}
+	if majority.QualityRating != "Good" {
+		t.Errorf("expected majority rating Good, got %s", majority.QualityRating)
+	}
+	if !majority.RelevanceCorrect {
+		t.Error("expected relevance to be correct by majority")
+	}
+	if math.Abs(majority.Disagreement-1.0/3.0) > 0.001 {
+		t.Errorf("expected disagreement 0.333, got %.3f", majority.Disagreement)
+	}
+	if math.Abs(majority.Confidence-2.0/3.0) > 0.001 {
+		t.Errorf("expected confidence 0.667, got %.3f", majority.Confidence)
+	}
+	if len(majority.JudgeVerdicts) != len(verdicts) {
+		t.Errorf("expected %d judge verdicts, got %d", len(verdicts), len(majority.JudgeVerdicts))
+	}
+
+	// (75 + 75 + 0) / 3 = 50
+	mean, _ := combineVerdicts(verdicts, AggregationMean)
+	if mean.QualityRating != "Fair" {
+		t.Errorf("expected mean rating Fair, got %s", mean.QualityRating)
+	}
+
+	if _, ok := combineVerdicts([]models.JudgeVerdict{{Error: "failed"}}, AggregationMajority); ok {
+		t.Error("expected no verdict when every judge failed")
+	}
+}
+
+func TestCombineVerdictsConfidenceUsesSamples(t *testing.T) {
+	verdicts := []models.JudgeVerdict{
+		{Judge: "a", QualityRating: "Good", SampleRatings: []string{"Good", "Good", "Fair"}},
+		{Judge: "b", QualityRating: "Good", SampleRatings: []string{"Good", "Fair", "Good"}},
+	}
+
+	result, ok := combineVerdicts(verdicts, AggregationMajority)
+	if !ok {
+		t.Fatal("expected a combined verdict")
+	}
+	if math.Abs(result.Confidence-4.0/6.0) > 0.001 {
+		t.Errorf("expected confidence 0.667, got %.3f", result.Confidence)
+	}
+	if result.Disagreement != 0 {
+		t.Errorf("expected no disagreement between judges, got %.3f", result.Disagreement)
+	}
+}
+
+func TestModalRatingTiesResolveLow(t *testing.T) {
+	if rating := modalRating([]string{"Excellent", "Fair"}); rating != "Fair" {
+		t.Errorf("expected Fair, got %s", rating)
+	}
+}
+
+func TestEvaluateWithJudgeSamples(t *testing.T) {
+	tests := []struct {
+		name       string
+		samples    int
+		respond    func(userPrompts []string) (string, error)
+		wantCalls  int
+		wantRating string
+		wantStatus string
+	}{
+		{
+			name:       "unset sample count asks once",
+			samples:    0,
+			respond:    func([]string) (string, error) { return `{"quality_rating": "Good", "relevance_correct": true}`, nil },
+			wantCalls:  1,
+			wantRating: "Good",
+		},
+		{
+			name:       "unset sample count reports a failed call",
+			samples:    0,
+			respond:    func([]string) (string, error) { return "", errors.New("connection refused") },
+			wantCalls:  1,
+			wantStatus: models.ItemStatusJudgeError,
+		},
+		{
+			name:       "unparseable responses",
+			samples:    3,
+			respond:    func([]string) (string, error) { return `{"quality_rating": "Great"}`, nil },
+			wantCalls:  3,
+			wantStatus: models.ItemStatusParseError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeJudgeClient{respond: tt.respond}
+			bs := &BenchmarkService{samples: tt.samples}
+			verdict := bs.evaluateWithJudge(judge{client: client}, nil, "system", "input")
+
+			if client.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", client.calls, tt.wantCalls)
+			}
+			if verdict.QualityRating != tt.wantRating || verdict.Status != tt.wantStatus {
+				t.Errorf("verdict = %+v, want rating %q and status %q", verdict, tt.wantRating, tt.wantStatus)
+			}
+			if tt.wantStatus != "" && verdict.Error == "" {
+				t.Error("failed verdict has no error")
+			}
+			if verdict.Usage == nil || verdict.Usage.Requests != tt.wantCalls {
+				t.Errorf("usage = %+v, want %d requests", verdict.Usage, tt.wantCalls)
+			}
+		})
+	}
+}
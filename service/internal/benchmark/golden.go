@@ -0,0 +1,272 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidGoldenDataset is returned when a golden dataset fails validation
+var ErrInvalidGoldenDataset = errors.New("invalid golden dataset")
+
+// goldenMu serialises golden dataset updates. The service is copied for re-evaluation, so the
+// lock lives at package level alongside the storage it guards.
+var goldenMu sync.Mutex
+
+// ListGoldenDatasets returns all golden datasets, oldest first
+func (bs *BenchmarkService) ListGoldenDatasets() ([]models.GoldenDataset, error) {
+	datasets, err := storage.ListGoldenDatasets()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(datasets, func(i, j int) bool {
+		return datasets[i].CreatedAt.Before(datasets[j].CreatedAt)
+	})
+	return datasets, nil
+}
+
+// GetGoldenDataset returns a golden dataset by ID
+func (bs *BenchmarkService) GetGoldenDataset(datasetID string) (*models.GoldenDataset, error) {
+	return storage.GetGoldenDataset(datasetID)
+}
+
+// CreateGoldenDataset validates and stores a new golden dataset
+func (bs *BenchmarkService) CreateGoldenDataset(request models.GoldenDatasetRequest) (*models.GoldenDataset, error) {
+	now := time.Now()
+	dataset := models.GoldenDataset{
+		ID:          uuid.NewString(),
+		Name:        request.Name,
+		Description: request.Description,
+		PersonaName: request.PersonaName,
+		Items:       request.Items,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := validateGoldenDataset(dataset); err != nil {
+		return nil, err
+	}
+
+	goldenMu.Lock()
+	defer goldenMu.Unlock()
+	if err := storage.SaveGoldenDataset(dataset); err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+// UpdateGoldenDataset applies the non-empty fields of a request to a golden dataset
+func (bs *BenchmarkService) UpdateGoldenDataset(datasetID string, request models.GoldenDatasetRequest) (*models.GoldenDataset, error) {
+	goldenMu.Lock()
+	defer goldenMu.Unlock()
+
+	dataset, err := storage.GetGoldenDataset(datasetID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Name != "" {
+		dataset.Name = request.Name
+	}
+	if request.Description != "" {
+		dataset.Description = request.Description
+	}
+	if request.PersonaName != "" {
+		dataset.PersonaName = request.PersonaName
+	}
+	if request.Items != nil {
+		dataset.Items = request.Items
+	}
+	dataset.UpdatedAt = time.Now()
+	if err := validateGoldenDataset(*dataset); err != nil {
+		return nil, err
+	}
+
+	if err := storage.SaveGoldenDataset(*dataset); err != nil {
+		return nil, err
+	}
+	return dataset, nil
+}
+
+// DeleteGoldenDataset deletes a golden dataset
+func (bs *BenchmarkService) DeleteGoldenDataset(datasetID string) error {
+	goldenMu.Lock()
+	defer goldenMu.Unlock()
+	return storage.DeleteGoldenDataset(datasetID)
+}
+
+// validateGoldenDataset checks that a dataset is named and its items have unique IDs
+func validateGoldenDataset(dataset models.GoldenDataset) error {
+	if dataset.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidGoldenDataset)
+	}
+	if len(dataset.Items) == 0 {
+		return fmt.Errorf("%w: at least one item is required", ErrInvalidGoldenDataset)
+	}
+	seen := make(map[string]bool, len(dataset.Items))
+	for i, item := range dataset.Items {
+		if item.ID == "" {
+			return fmt.Errorf("%w: item %d has no id", ErrInvalidGoldenDataset, i)
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("%w: duplicate item id %q", ErrInvalidGoldenDataset, item.ID)
+		}
+		seen[item.ID] = true
+	}
+	return nil
+}
+
+// ScoreGoldenDataset scores a run's outputs against a golden dataset. Summaries are compared
+// to reference summaries lexically, and semantically when an embedding model is configured.
+func (bs *BenchmarkService) ScoreGoldenDataset(datasetID, runID string) (*models.GoldenScore, error) {
+	dataset, err := storage.GetGoldenDataset(datasetID)
+	if err != nil {
+		return nil, err
+	}
+	runData, err := storage.GetRunData(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run data: %w", err)
+	}
+
+	score, outputs := scoreGolden(dataset, runData)
+
+	if embedder := bs.newEmbedder(); embedder != nil {
+		var total float64
+		compared := 0
+		for i := range score.Items {
+			item := &score.Items[i]
+			// Only items compared lexically have both a reference and an output summary
+			if item.Rouge1 == nil {
+				continue
+			}
+			reference := referenceSummary(dataset, item.ItemID)
+			embeddings, _, err := embedder.Embeddings([]string{truncateForEmbedding(reference), truncateForEmbedding(outputs[item.ItemID].Summary)})
+			if err != nil {
+				log.Printf("Error embedding summaries of golden item %s: %v", item.ItemID, err)
+				continue
+			}
+			similarity := cosineSimilarity(embeddings[1], embeddings[0])
+			item.SemanticSimilarity = &similarity
+			total += similarity
+			compared++
+		}
+		if compared > 0 && score.Reference != nil {
+			mean := total / float64(compared)
+			score.Reference.SemanticSimilarity = &mean
+		}
+	}
+
+	return score, nil
+}
+
+// referenceSummary returns the reference summary of a golden item, if any
+func referenceSummary(dataset *models.GoldenDataset, itemID string) string {
+	for _, item := range dataset.Items {
+		if item.ID == itemID {
+			return item.ReferenceSummary
+		}
+	}
+	return ""
+}
+
+// outputsByID maps each processed item in a run to its ID and its source entry's ID, so that
+// golden items and annotations may be keyed by either
+func outputsByID(entrySummaries []anpmodels.EntrySummary) map[string]anpmodels.Item {
+	items := itemsByID(entrySummaries)
+	for _, summary := range entrySummaries {
+		if id := summary.Results.Entry.ID; id != "" {
+			if _, ok := items[id]; !ok {
+				items[id] = summary.Results
+			}
+		}
+	}
+	return items
+}
+
+// scoreGolden computes relevance and lexical reference scores of a run against a golden dataset.
+// It also returns the run's outputs by ID for further comparison.
+func scoreGolden(dataset *models.GoldenDataset, runData *models.PersistedRunData) (*models.GoldenScore, map[string]anpmodels.Item) {
+	outputs := outputsByID(runData.EntrySummaries)
+	score := &models.GoldenScore{
+		DatasetID:   dataset.ID,
+		DatasetName: dataset.Name,
+		RunID:       runData.RunID,
+		ScoredAt:    time.Now(),
+		GoldenItems: len(dataset.Items),
+		Items:       []models.GoldenItemScore{},
+	}
+
+	var sum1, sum2, sumL models.RougeScore
+	compared := 0
+	for _, golden := range dataset.Items {
+		output, ok := outputs[golden.ID]
+		if !ok {
+			score.MissingItems = append(score.MissingItems, golden.ID)
+			continue
+		}
+		score.MatchedItems++
+
+		item := models.GoldenItemScore{
+			ItemID:            golden.ID,
+			ExpectedRelevant:  golden.ExpectedRelevant,
+			PredictedRelevant: output.IsRelevant,
+		}
+		switch {
+		case golden.ExpectedRelevant && output.IsRelevant:
+			score.Relevance.TruePositives++
+		case !golden.ExpectedRelevant && output.IsRelevant:
+			score.Relevance.FalsePositives++
+		case golden.ExpectedRelevant && !output.IsRelevant:
+			score.Relevance.FalseNegatives++
+		default:
+			score.Relevance.TrueNegatives++
+		}
+
+		if golden.ReferenceSummary != "" && output.Summary != "" {
+			reference := tokenize(golden.ReferenceSummary)
+			candidate := tokenize(output.Summary)
+			r1, r2, rl := rougeN(reference, candidate, 1), rougeN(reference, candidate, 2), rougeL(reference, candidate)
+			item.Rouge1, item.RougeL = &r1, &rl
+			sum1 = addRougeScores(sum1, r1)
+			sum2 = addRougeScores(sum2, r2)
+			sumL = addRougeScores(sumL, rl)
+			compared++
+		}
+		score.Items = append(score.Items, item)
+	}
+
+	score.Relevance = relevanceScores(score.Relevance)
+	if compared > 0 {
+		n := float64(compared)
+		score.Reference = &models.ReferenceSimilarity{
+			ItemsCompared: compared,
+			Rouge1:        scaleRougeScore(sum1, 1/n),
+			Rouge2:        scaleRougeScore(sum2, 1/n),
+			RougeL:        scaleRougeScore(sumL, 1/n),
+		}
+	}
+	return score, outputs
+}
+
+// relevanceScores derives precision, recall, F1 and accuracy from the confusion counts
+func relevanceScores(s models.RelevanceScore) models.RelevanceScore {
+	if predicted := s.TruePositives + s.FalsePositives; predicted > 0 {
+		s.Precision = float64(s.TruePositives) / float64(predicted)
+	}
+	if actual := s.TruePositives + s.FalseNegatives; actual > 0 {
+		s.Recall = float64(s.TruePositives) / float64(actual)
+	}
+	if s.Precision+s.Recall > 0 {
+		s.F1 = 2 * s.Precision * s.Recall / (s.Precision + s.Recall)
+	}
+	if total := s.TruePositives + s.FalsePositives + s.FalseNegatives + s.TrueNegatives; total > 0 {
+		s.Accuracy = float64(s.TruePositives+s.TrueNegatives) / float64(total)
+	}
+	return s
+}
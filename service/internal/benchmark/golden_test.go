@@ -0,0 +1,116 @@
+package benchmark
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+func TestScoreGolden(t *testing.T) {
+	dataset := &models.GoldenDataset{
+		ID:   "golden",
+		Name: "Golden",
+		Items: []models.GoldenItem{
+			{ID: "tp", ExpectedRelevant: true, ReferenceSummary: "the new model beats the old model"},
+			{ID: "fp", ExpectedRelevant: false},
+			{ID: "fn", ExpectedRelevant: true},
+			{ID: "tn", ExpectedRelevant: false},
+			{ID: "entry-only", ExpectedRelevant: true, ReferenceSummary: "a quantised release"},
+			{ID: "missing", ExpectedRelevant: true},
+		},
+	}
+	runData := &models.PersistedRunData{RunID: "run"}
+	runData.EntrySummaries = []anpmodels.EntrySummary{
+		{Results: anpmodels.Item{ID: "tp", IsRelevant: true, Summary: "the new model beats the old model"}},
+		{Results: anpmodels.Item{ID: "fp", IsRelevant: true}},
+		{Results: anpmodels.Item{ID: "fn", IsRelevant: false}},
+		{Results: anpmodels.Item{ID: "tn", IsRelevant: false}},
+		{Results: anpmodels.Item{ID: "other", IsRelevant: true, Summary: "something unrelated", Entry: anpmodels.Entry{ID: "entry-only"}}},
+	}
+
+	got, outputs := scoreGolden(dataset, runData)
+
+	if got.GoldenItems != 6 || got.MatchedItems != 5 {
+		t.Errorf("GoldenItems = %d, MatchedItems = %d, want 6 and 5", got.GoldenItems, got.MatchedItems)
+	}
+	if !reflect.DeepEqual(got.MissingItems, []string{"missing"}) {
+		t.Errorf("MissingItems = %v, want [missing]", got.MissingItems)
+	}
+	if _, ok := outputs["entry-only"]; !ok {
+		t.Error("outputs should be keyed by source entry ID as well as item ID")
+	}
+
+	relevance := got.Relevance
+	if relevance.TruePositives != 2 || relevance.FalsePositives != 1 || relevance.FalseNegatives != 1 || relevance.TrueNegatives != 1 {
+		t.Errorf("confusion = %+v, want TP 2, FP 1, FN 1, TN 1", relevance)
+	}
+	if math.Abs(relevance.Precision-2.0/3) > 1e-9 || math.Abs(relevance.Recall-2.0/3) > 1e-9 || math.Abs(relevance.Accuracy-0.6) > 1e-9 {
+		t.Errorf("precision, recall, accuracy = %.3f, %.3f, %.3f, want 0.667, 0.667, 0.6", relevance.Precision, relevance.Recall, relevance.Accuracy)
+	}
+
+	if got.Reference == nil || got.Reference.ItemsCompared != 2 {
+		t.Fatalf("Reference = %+v, want 2 items compared", got.Reference)
+	}
+	if got.Items[0].Rouge1 == nil || got.Items[0].Rouge1.F1 != 1 {
+		t.Errorf("identical summary Rouge1 = %+v, want F1 1", got.Items[0].Rouge1)
+	}
+	if got.Reference.Rouge1.F1 != 0.5 {
+		t.Errorf("mean Rouge1 F1 = %v, want 0.5", got.Reference.Rouge1.F1)
+	}
+}
+
+func TestRelevanceScores(t *testing.T) {
+	tests := []struct {
+		name string
+		in   models.RelevanceScore
+		want models.RelevanceScore
+	}{
+		{name: "empty", in: models.RelevanceScore{}, want: models.RelevanceScore{}},
+		{
+			name: "nothing predicted relevant",
+			in:   models.RelevanceScore{FalseNegatives: 2, TrueNegatives: 2},
+			want: models.RelevanceScore{FalseNegatives: 2, TrueNegatives: 2, Accuracy: 0.5},
+		},
+		{
+			name: "perfect",
+			in:   models.RelevanceScore{TruePositives: 3, TrueNegatives: 1},
+			want: models.RelevanceScore{TruePositives: 3, TrueNegatives: 1, Precision: 1, Recall: 1, F1: 1, Accuracy: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relevanceScores(tt.in); got != tt.want {
+				t.Errorf("relevanceScores() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGoldenDataset(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataset models.GoldenDataset
+		wantErr bool
+	}{
+		{name: "valid", dataset: models.GoldenDataset{Name: "a", Items: []models.GoldenItem{{ID: "1"}, {ID: "2"}}}},
+		{name: "no name", dataset: models.GoldenDataset{Items: []models.GoldenItem{{ID: "1"}}}, wantErr: true},
+		{name: "no items", dataset: models.GoldenDataset{Name: "a"}, wantErr: true},
+		{name: "item without id", dataset: models.GoldenDataset{Name: "a", Items: []models.GoldenItem{{Title: "t"}}}, wantErr: true},
+		{name: "duplicate ids", dataset: models.GoldenDataset{Name: "a", Items: []models.GoldenItem{{ID: "1"}, {ID: "1"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGoldenDataset(tt.dataset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateGoldenDataset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidGoldenDataset) {
+				t.Errorf("error %v does not wrap ErrInvalidGoldenDataset", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package benchmark
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+const (
+	spanNumber  = "number"
+	spanVersion = "version"
+	spanURL     = "url"
+	spanEntity  = "entity"
+)
+
+var (
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+	// Versions have a v prefix or at least two dots, e.g. v2, 1.2.3
+	versionPattern = regexp.MustCompile(`\b[vV]\d+(?:\.\d+)*\b|\b\d+\.\d+\.\d+(?:\.\d+)?\b`)
+	// Numbers may carry thousands separators, decimals and a magnitude or unit suffix, e.g. 1,024, 3.5, 70B, 3.5GB, 15%
+	numberPattern = regexp.MustCompile(`\b\d+(?:,\d{3})*(?:\.\d+)?(?:[A-Za-z]+\b|%|\b)`)
+	// Fact tokens are whole numbers, including decimals and version strings, or runs of letters
+	factTokenPattern = regexp.MustCompile(`\d+(?:,\d{3})*(?:\.\d+)*|\p{L}+`)
+	// Entities are runs of capitalised words, allowing hyphenated or dotted suffixes such as GPT-4o or Qwen2.5,
+	// and numbered continuations such as Llama 4 Scout
+	entityPattern = regexp.MustCompile(`\b[A-Z][A-Za-z0-9]*(?:[-.][A-Za-z0-9]+)*(?:\s+(?:[A-Z][A-Za-z0-9]*(?:[-.][A-Za-z0-9]+)*|\d+(?:\.\d+)?\b))*`)
+)
+
+// commonCapitalised are words that are often capitalised without naming an entity
+var commonCapitalised = map[string]bool{
+	"a": true, "an": true, "the": true, "this": true, "that": true, "these": true, "those": true,
+	"it": true, "its": true, "in": true, "on": true, "at": true, "for": true, "with": true,
+	"and": true, "but": true, "or": true, "if": true, "i": true, "we": true, "they": true,
+	"users": true, "some": true, "many": true, "overall": true, "however": true, "also": true,
+}
+
+// extractedSpan is a candidate fact found in a summary
+type extractedSpan struct {
+	text string
+	kind string
+}
+
+// extractSpans finds numbers, version strings, URLs and capitalised entities in text
+func extractSpans(text string) []extractedSpan {
+	var spans []extractedSpan
+	seen := make(map[string]bool)
+	add := func(span, kind string) {
+		key := kind + ":" + strings.ToLower(span)
+		if span == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		spans = append(spans, extractedSpan{text: span, kind: kind})
+	}
+
+	// URLs are removed before looking for other spans so their parts are not checked twice
+	for _, u := range urlPattern.FindAllString(text, -1) {
+		add(strings.TrimRight(u, ".,;:!?"), spanURL)
+	}
+	text = urlPattern.ReplaceAllString(text, " ")
+
+	for _, v := range versionPattern.FindAllString(text, -1) {
+		add(v, spanVersion)
+	}
+	text = versionPattern.ReplaceAllString(text, " ")
+
+	for _, loc := range entityPattern.FindAllStringIndex(text, -1) {
+		entity := text[loc[0]:loc[1]]
+		words := strings.Fields(entity)
+		// Drop leading words that are only capitalised because they start a sentence
+		for len(words) > 0 && commonCapitalised[strings.ToLower(words[0])] {
+			words = words[1:]
+		}
+		if len(words) == 0 {
+			continue
+		}
+		if len(words) == 1 && isSentenceStart(text, loc[0]) && !hasInnerCapitalOrDigit(words[0]) {
+			continue
+		}
+		add(strings.Join(words, " "), spanEntity)
+	}
+	text = entityPattern.ReplaceAllString(text, " ")
+
+	for _, n := range numberPattern.FindAllString(text, -1) {
+		add(n, spanNumber)
+	}
+
+	return spans
+}
+
+// isSentenceStart reports whether the position begins a sentence
+func isSentenceStart(text string, pos int) bool {
+	prefix := strings.TrimRight(text[:pos], " \t\r\n\"'(")
+	return prefix == "" || strings.HasSuffix(prefix, ".") || strings.HasSuffix(prefix, "!") ||
+		strings.HasSuffix(prefix, "?") || strings.HasSuffix(prefix, ":")
+}
+
+// hasInnerCapitalOrDigit reports whether a word looks like a name rather than an ordinary word, e.g. DeepSeek or Llama3
+func hasInnerCapitalOrDigit(word string) bool {
+	for i, r := range word {
+		if (i > 0 && r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceFacts is a normalised view of the source used for support checks
+type sourceFacts struct {
+	text    string          // Lowercased source with collapsed whitespace
+	phrase  string          // Normalised fact tokens, space separated and padded at both ends
+	numbers map[string]bool // Whole numbers and version strings without thousands separators
+}
+
+func newSourceFacts(source string) sourceFacts {
+	tokens := factTokens(source)
+	facts := sourceFacts{
+		text:    strings.Join(strings.Fields(strings.ToLower(source)), " "),
+		phrase:  " " + strings.Join(tokens, " ") + " ",
+		numbers: make(map[string]bool),
+	}
+	for _, token := range tokens {
+		if isNumericToken(token) {
+			facts.numbers[token] = true
+		}
+	}
+	return facts
+}
+
+// factTokens lowercases text into words and whole numbers. Numbers keep their decimal and version
+// dots but lose thousands separators, and letters and digits are split apart, so "Qwen2.5",
+// "Qwen-2.5" and "Qwen 2.5" all become "qwen 2.5".
+func factTokens(text string) []string {
+	tokens := factTokenPattern.FindAllString(strings.ToLower(text), -1)
+	for i, token := range tokens {
+		if isNumericToken(token) {
+			tokens[i] = strings.ReplaceAll(token, ",", "")
+		}
+	}
+	return tokens
+}
+
+// isNumericToken reports whether a fact token is a number rather than a word
+func isNumericToken(token string) bool {
+	return token != "" && token[0] >= '0' && token[0] <= '9'
+}
+
+// supports reports whether the source backs up the span
+func (f sourceFacts) supports(span extractedSpan) bool {
+	lower := strings.ToLower(span.text)
+
+	switch span.kind {
+	case spanURL:
+		// Ignore scheme and trailing slash differences
+		trimmed := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(lower, "https://"), "http://"), "/")
+		return strings.Contains(f.text, lower) || strings.Contains(f.text, trimmed)
+	case spanNumber, spanVersion:
+		// The whole value must appear, so v0.3.9 is not backed by v0.3.1. Prefixes and magnitude
+		// suffixes are ignored, so 70B is backed by "70 billion".
+		numbers := 0
+		for _, token := range factTokens(lower) {
+			if !isNumericToken(token) {
+				continue
+			}
+			numbers++
+			if !f.numbers[token] {
+				return false
+			}
+		}
+		return numbers > 0
+	case spanEntity:
+		// The entity must appear as a contiguous phrase, so Qwen 3.5 is not backed by "Qwen 2.5 ... 3.5GB"
+		tokens := factTokens(lower)
+		return len(tokens) > 0 && strings.Contains(f.phrase, " "+strings.Join(tokens, " ")+" ")
+	}
+	return false
+}
+
+// checkHallucinations flags facts in the summary that do not appear in the source
+func checkHallucinations(source, summary string) models.HallucinationCheck {
+	facts := newSourceFacts(source)
+	spans := extractSpans(summary)
+
+	check := models.HallucinationCheck{CheckedSpans: len(spans)}
+	for _, span := range spans {
+		if !facts.supports(span) {
+			check.UnsupportedSpans = append(check.UnsupportedSpans, models.UnsupportedSpan{Text: span.text, Kind: span.kind})
+		}
+	}
+	return check
+}
+
+// hallucinationSummary returns the share of checked spans that were unsupported and the items with any unsupported span
+func hallucinationSummary(evaluations map[string]models.EvaluationResult) (float64, []string) {
+	var checked, unsupported int
+	var items []string
+	for id, eval := range evaluations {
+		if eval.Hallucination == nil {
+			continue
+		}
+		checked += eval.Hallucination.CheckedSpans
+		unsupported += len(eval.Hallucination.UnsupportedSpans)
+		if len(eval.Hallucination.UnsupportedSpans) > 0 {
+			items = append(items, id)
+		}
+	}
+	sort.Strings(items)
+
+	if checked == 0 {
+		return 0, items
+	}
+	return float64(unsupported) / float64(checked), items
+}
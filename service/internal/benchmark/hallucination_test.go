@@ -0,0 +1,108 @@
+package benchmark
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestExtractSpans(t *testing.T) {
+	summary := "The release of Qwen2.5 72B brings llama.cpp v0.3.1 support. Details at https://example.com/post. Users report 15% faster inference than GPT-4o."
+
+	spans := extractSpans(summary)
+	found := make(map[string]string)
+	for _, span := range spans {
+		found[span.text] = span.kind
+	}
+
+	expected := map[string]string{
+		"Qwen2.5":                  spanEntity,
+		"72B":                      spanNumber,
+		"v0.3.1":                   spanVersion,
+		"https://example.com/post": spanURL,
+		"15%":                      spanNumber,
+		"GPT-4o":                   spanEntity,
+	}
+	for text, kind := range expected {
+		if found[text] != kind {
+			t.Errorf("expected %q to be extracted as %s, got %q", text, kind, found[text])
+		}
+	}
+	if _, ok := found["The"]; ok {
+		t.Error("sentence-initial common words should not be extracted as entities")
+	}
+}
+
+func TestCheckHallucinations(t *testing.T) {
+	source := `ID: t3_abc
+Title: Meta releases Llama 4 Scout
+Content: Meta's new Llama 4 Scout model has 109 billion parameters and a 10M context window.
+See https://ai.meta.com/blog/llama-4/ for details.`
+
+	release := `Title: llama.cpp v0.3.1 adds Qwen 2.5 support
+Content: The Qwen 2.5 quantised weights are 3.5GB. Upgrade to v0.3.1 or later.`
+
+	tests := []struct {
+		name        string
+		source      string
+		summary     string
+		unsupported []string
+	}{
+		{
+			name:        "faithful summary",
+			source:      source,
+			summary:     "Meta released Llama 4 Scout with 109B parameters and a 10M context window (http://ai.meta.com/blog/llama-4).",
+			unsupported: nil,
+		},
+		{
+			name:        "wrong numbers and entities",
+			source:      source,
+			summary:     "Meta released Llama 5 Scout with 70B parameters, beating Gemini on every benchmark.",
+			unsupported: []string{"70B", "Gemini", "Llama 5 Scout"},
+		},
+		{
+			name:        "wrong url",
+			source:      source,
+			summary:     "More at https://example.com/llama.",
+			unsupported: []string{"https://example.com/llama"},
+		},
+		{
+			name:        "spacing variants of an entity",
+			source:      release,
+			summary:     "The update supports Qwen2.5 and Qwen-2.5 models of 3.5GB in v0.3.1.",
+			unsupported: nil,
+		},
+		{
+			name:        "wrong minor version",
+			source:      release,
+			summary:     "Upgrade llama.cpp to v0.3.9 for support, not v0.3.2.",
+			unsupported: []string{"v0.3.2", "v0.3.9"},
+		},
+		{
+			name:        "wrong entity version",
+			source:      release,
+			summary:     "The release adds support for Qwen 3.5 models.",
+			unsupported: []string{"Qwen 3.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkHallucinations(tt.source, tt.summary)
+			var got []string
+			for _, span := range check.UnsupportedSpans {
+				got = append(got, span.Text)
+			}
+			sort.Strings(got)
+			sort.Strings(tt.unsupported)
+			if len(got) != len(tt.unsupported) {
+				t.Fatalf("expected unsupported spans %v, got %v", tt.unsupported, got)
+			}
+			for i := range got {
+				if got[i] != tt.unsupported[i] {
+					t.Errorf("expected unsupported spans %v, got %v", tt.unsupported, got)
+					break
+				}
+			}
+		})
+	}
+}
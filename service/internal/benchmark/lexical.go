@@ -0,0 +1,153 @@
+package benchmark
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// tokenize lowercases text and splits it into runs of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ngrams counts the n-grams in a token sequence
+func ngrams(tokens []string, n int) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i+n <= len(tokens); i++ {
+		counts[strings.Join(tokens[i:i+n], " ")]++
+	}
+	return counts
+}
+
+// newRougeScore builds a score from the overlap and the candidate and reference sizes
+func newRougeScore(overlap, candidateSize, referenceSize int) models.RougeScore {
+	var score models.RougeScore
+	if candidateSize > 0 {
+		score.Precision = float64(overlap) / float64(candidateSize)
+	}
+	if referenceSize > 0 {
+		score.Recall = float64(overlap) / float64(referenceSize)
+	}
+	if score.Precision+score.Recall > 0 {
+		score.F1 = 2 * score.Precision * score.Recall / (score.Precision + score.Recall)
+	}
+	return score
+}
+
+// rougeN computes ROUGE-N of a candidate against a reference using clipped n-gram counts
+func rougeN(reference, candidate []string, n int) models.RougeScore {
+	refCounts := ngrams(reference, n)
+	candCounts := ngrams(candidate, n)
+
+	overlap := 0
+	for gram, count := range candCounts {
+		overlap += min(count, refCounts[gram])
+	}
+	return newRougeScore(overlap, max(len(candidate)-n+1, 0), max(len(reference)-n+1, 0))
+}
+
+// rougeL computes ROUGE-L from the longest common subsequence of the two token sequences
+func rougeL(reference, candidate []string) models.RougeScore {
+	return newRougeScore(lcsLength(reference, candidate), len(candidate), len(reference))
+}
+
+// lcsLength returns the length of the longest common subsequence using two rows of the DP table
+func lcsLength(a, b []string) int {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else {
+				curr[j] = max(prev[j], curr[j-1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// computeLexicalMetrics compares a summary to its source material without a judge
+func computeLexicalMetrics(source, summary string) models.LexicalMetrics {
+	sourceTokens := tokenize(source)
+	summaryTokens := tokenize(summary)
+
+	metrics := models.LexicalMetrics{
+		Rouge1:        rougeN(sourceTokens, summaryTokens, 1),
+		Rouge2:        rougeN(sourceTokens, summaryTokens, 2),
+		RougeL:        rougeL(sourceTokens, summaryTokens),
+		SummaryLength: float64(len(summaryTokens)),
+		SourceLength:  float64(len(sourceTokens)),
+	}
+
+	if len(sourceTokens) > 0 {
+		metrics.CompressionRatio = float64(len(summaryTokens)) / float64(len(sourceTokens))
+	}
+
+	// Novel bigrams are a proxy for abstractiveness, and at the extreme, hallucination
+	summaryBigrams := ngrams(summaryTokens, 2)
+	sourceBigrams := ngrams(sourceTokens, 2)
+	total, novel := 0, 0
+	for gram, count := range summaryBigrams {
+		total += count
+		if sourceBigrams[gram] == 0 {
+			novel += count
+		}
+	}
+	if total > 0 {
+		metrics.NovelBigramRatio = float64(novel) / float64(total)
+	}
+
+	return metrics
+}
+
+// averageLexicalMetrics returns the mean of the lexical metrics across evaluations, or nil if none have them
+func averageLexicalMetrics(evaluations map[string]models.EvaluationResult) *models.LexicalMetrics {
+	var sum models.LexicalMetrics
+	count := 0
+	for _, eval := range evaluations {
+		if eval.Lexical == nil {
+			continue
+		}
+		m := eval.Lexical
+		sum.Rouge1 = addRougeScores(sum.Rouge1, m.Rouge1)
+		sum.Rouge2 = addRougeScores(sum.Rouge2, m.Rouge2)
+		sum.RougeL = addRougeScores(sum.RougeL, m.RougeL)
+		sum.CompressionRatio += m.CompressionRatio
+		sum.SummaryLength += m.SummaryLength
+		sum.SourceLength += m.SourceLength
+		sum.NovelBigramRatio += m.NovelBigramRatio
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+
+	n := float64(count)
+	return &models.LexicalMetrics{
+		Rouge1:           scaleRougeScore(sum.Rouge1, 1/n),
+		Rouge2:           scaleRougeScore(sum.Rouge2, 1/n),
+		RougeL:           scaleRougeScore(sum.RougeL, 1/n),
+		CompressionRatio: sum.CompressionRatio / n,
+		SummaryLength:    sum.SummaryLength / n,
+		SourceLength:     sum.SourceLength / n,
+		NovelBigramRatio: sum.NovelBigramRatio / n,
+	}
+}
+
+func addRougeScores(a, b models.RougeScore) models.RougeScore {
+	return models.RougeScore{Precision: a.Precision + b.Precision, Recall: a.Recall + b.Recall, F1: a.F1 + b.F1}
+}
+
+func scaleRougeScore(s models.RougeScore, factor float64) models.RougeScore {
+	return models.RougeScore{Precision: s.Precision * factor, Recall: s.Recall * factor, F1: s.F1 * factor}
+}
@@ -0,0 +1,66 @@
+package benchmark
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("Qwen3-32B beats GPT-4o, apparently!")
+	expected := []string{"qwen3", "32b", "beats", "gpt", "4o", "apparently"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, tokens)
+	}
+	for i := range expected {
+		if tokens[i] != expected[i] {
+			t.Errorf("token %d: expected %q, got %q", i, expected[i], tokens[i])
+		}
+	}
+}
+
+func TestRouge(t *testing.T) {
+	reference := tokenize("the cat sat on the mat")
+	candidate := tokenize("the cat lay on the mat")
+
+	tests := []struct {
+		name     string
+		score    models.RougeScore
+		expected float64
+	}{
+		{name: "rouge-1", score: rougeN(reference, candidate, 1), expected: 5.0 / 6.0},
+		{name: "rouge-2", score: rougeN(reference, candidate, 2), expected: 3.0 / 5.0},
+		{name: "rouge-l", score: rougeL(reference, candidate), expected: 5.0 / 6.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.score.F1-tt.expected) > 0.001 {
+				t.Errorf("expected F1 %.3f, got %.3f", tt.expected, tt.score.F1)
+			}
+		})
+	}
+}
+
+func TestComputeLexicalMetrics(t *testing.T) {
+	source := "a new model was released today with strong benchmark results"
+	summary := "a new model was released with invented numbers"
+
+	metrics := computeLexicalMetrics(source, summary)
+	if metrics.SummaryLength != 8 || metrics.SourceLength != 10 {
+		t.Errorf("unexpected lengths: summary %.0f, source %.0f", metrics.SummaryLength, metrics.SourceLength)
+	}
+	if math.Abs(metrics.CompressionRatio-0.8) > 0.001 {
+		t.Errorf("expected compression ratio 0.8, got %.3f", metrics.CompressionRatio)
+	}
+	// 7 summary bigrams, of which "released with", "with invented" and "invented numbers" are novel
+	if math.Abs(metrics.NovelBigramRatio-3.0/7.0) > 0.001 {
+		t.Errorf("expected novel bigram ratio 0.429, got %.3f", metrics.NovelBigramRatio)
+	}
+
+	empty := computeLexicalMetrics("", "")
+	if empty.Rouge1.F1 != 0 || empty.CompressionRatio != 0 || empty.NovelBigramRatio != 0 {
+		t.Errorf("expected zero metrics for empty input, got %+v", empty)
+	}
+}
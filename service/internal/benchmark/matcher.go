@@ -0,0 +1,184 @@
+package benchmark
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+const (
+	// MatchStructured means the item was paired with the raw input submitted alongside it
+	MatchStructured = "structured"
+	// MatchPattern means the item was found by an ID extracted from another entry's raw input
+	MatchPattern = "pattern"
+)
+
+// DefaultEntryIDPatterns extracts IDs from lines such as "ID: t3_abc123"
+var DefaultEntryIDPatterns = []string{`^ID:\s*(\S+)`}
+
+// MatchReport describes how processed items were paired with their raw input
+type MatchReport struct {
+	Items     map[string]anpmodels.Item // Processed items by ID
+	ItemOrder []string                  // Item IDs in submission order
+	Sources   map[string]string         // Raw input by item ID, for matched items only
+	Methods   map[string]string         // Match method by item ID, for matched items only
+	Unmatched []string                  // Processed items with no identifiable raw input
+	Dropped   []string                  // Raw inputs with an ID but no processed item
+	// UnidentifiedInputs counts raw inputs without a processed item from which no ID could be extracted
+	UnidentifiedInputs int
+}
+
+// EntryMatcher pairs processed items with the raw input they were generated from
+type EntryMatcher interface {
+	Match(entrySummaries []anpmodels.EntrySummary) MatchReport
+	// Describe returns a short description of the matching rules, for diagnostics
+	Describe() []string
+}
+
+// PatternEntryMatcher matches on structured fields first, then falls back to IDs
+// extracted from raw input with regular expressions
+type PatternEntryMatcher struct {
+	patterns []*regexp.Regexp
+}
+
+// NewPatternEntryMatcher compiles the given patterns. Each pattern must have one
+// capture group for the ID and is applied per line.
+func NewPatternEntryMatcher(patterns []string) (*PatternEntryMatcher, error) {
+	m := &PatternEntryMatcher{}
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?m)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry ID pattern %q: %w", p, err)
+		}
+		if re.NumSubexp() < 1 {
+			return nil, fmt.Errorf("entry ID pattern %q must have a capture group for the ID", p)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	return m, nil
+}
+
+// Describe lists the patterns used for fallback matching
+func (m *PatternEntryMatcher) Describe() []string {
+	described := make([]string, 0, len(m.patterns))
+	for _, re := range m.patterns {
+		described = append(described, re.String())
+	}
+	return described
+}
+
+// extractIDs returns every ID the patterns find in the raw input
+func (m *PatternEntryMatcher) extractIDs(rawInput string) []string {
+	var ids []string
+	for _, re := range m.patterns {
+		for _, match := range re.FindAllStringSubmatch(rawInput, -1) {
+			if match[1] != "" {
+				ids = append(ids, match[1])
+			}
+		}
+	}
+	return ids
+}
+
+// Match pairs each processed item with its raw input
+func (m *PatternEntryMatcher) Match(entrySummaries []anpmodels.EntrySummary) MatchReport {
+	report := MatchReport{
+		Items:   make(map[string]anpmodels.Item),
+		Sources: make(map[string]string),
+		Methods: make(map[string]string),
+	}
+
+	// Index the IDs in each raw input
+	rawIDs := make([][]string, len(entrySummaries))
+	patternIndex := make(map[string]int)
+	for i, summary := range entrySummaries {
+		rawIDs[i] = m.extractIDs(summary.RawInput)
+		for _, id := range rawIDs[i] {
+			if _, exists := patternIndex[id]; !exists {
+				patternIndex[id] = i
+			}
+		}
+	}
+
+	usedInputs := make(map[int]bool)
+	for i, summary := range entrySummaries {
+		item := summary.Results
+		id := item.ID
+		if id == "" {
+			id = item.Entry.ID
+		}
+		if id == "" {
+			continue
+		}
+		if _, seen := report.Items[id]; seen {
+			log.Printf("Warning: Duplicate processed item ID: %s", id)
+			continue
+		}
+		report.Items[id] = item
+		report.ItemOrder = append(report.ItemOrder, id)
+
+		candidates := []string{item.ID, item.Entry.ID}
+
+		// Structured: the raw input submitted with this item, unless it names a different entry
+		if summary.RawInput != "" && (len(rawIDs[i]) == 0 || containsAny(rawIDs[i], candidates)) {
+			report.Sources[id] = summary.RawInput
+			report.Methods[id] = MatchStructured
+			usedInputs[i] = true
+			continue
+		}
+
+		// Pattern: an ID extracted from any raw input
+		matched := false
+		for _, candidate := range candidates {
+			if idx, ok := patternIndex[candidate]; ok && candidate != "" {
+				report.Sources[id] = entrySummaries[idx].RawInput
+				report.Methods[id] = MatchPattern
+				usedInputs[idx] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report.Unmatched = append(report.Unmatched, id)
+		}
+	}
+
+	// Raw inputs that no processed item claimed were dropped from the output
+	dropped := make(map[string]bool)
+	for i, summary := range entrySummaries {
+		if usedInputs[i] || summary.RawInput == "" {
+			continue
+		}
+		if len(rawIDs[i]) == 0 {
+			if summary.Results.ID == "" && summary.Results.Entry.ID == "" {
+				report.UnidentifiedInputs++
+			}
+			continue
+		}
+		for _, id := range rawIDs[i] {
+			if _, processed := report.Items[id]; !processed && !dropped[id] {
+				dropped[id] = true
+				report.Dropped = append(report.Dropped, id)
+			}
+		}
+	}
+
+	sort.Strings(report.Unmatched)
+	sort.Strings(report.Dropped)
+	return report
+}
+
+// containsAny reports whether any non-empty candidate is in ids
+func containsAny(ids []string, candidates []string) bool {
+	for _, id := range ids {
+		for _, candidate := range candidates {
+			if candidate != "" && id == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
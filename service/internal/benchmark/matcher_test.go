@@ -0,0 +1,108 @@
+package benchmark
+
+import (
+	"reflect"
+	"testing"
+
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+func entry(id, entryID, rawInput string) anpmodels.EntrySummary {
+	summary := anpmodels.EntrySummary{RawInput: rawInput}
+	summary.Results.ID = id
+	summary.Results.Entry.ID = entryID
+	return summary
+}
+
+func TestPatternEntryMatcher(t *testing.T) {
+	tests := []struct {
+		name       string
+		patterns   []string
+		entries    []anpmodels.EntrySummary
+		methods    map[string]string
+		sources    map[string]string
+		unmatched  []string
+		dropped    []string
+		unknownRaw int
+	}{
+		{
+			name:     "structured match without an ID line",
+			patterns: DefaultEntryIDPatterns,
+			entries:  []anpmodels.EntrySummary{entry("a", "", "Title: A")},
+			methods:  map[string]string{"a": MatchStructured},
+			sources:  map[string]string{"a": "Title: A"},
+		},
+		{
+			name:     "entry ID used when result ID is empty",
+			patterns: DefaultEntryIDPatterns,
+			entries:  []anpmodels.EntrySummary{entry("", "a", "ID: a\nTitle: A")},
+			methods:  map[string]string{"a": MatchStructured},
+			sources:  map[string]string{"a": "ID: a\nTitle: A"},
+		},
+		{
+			name:     "raw input naming another entry falls back to pattern",
+			patterns: DefaultEntryIDPatterns,
+			entries: []anpmodels.EntrySummary{
+				entry("a", "", "ID: b\nTitle: B"),
+				entry("b", "", "ID: a\nTitle: A"),
+			},
+			methods: map[string]string{"a": MatchPattern, "b": MatchPattern},
+			sources: map[string]string{"a": "ID: a\nTitle: A", "b": "ID: b\nTitle: B"},
+		},
+		{
+			name:     "custom pattern",
+			patterns: []string{`^Post-Id\s*=\s*(\w+)`},
+			entries: []anpmodels.EntrySummary{
+				entry("a", "", ""),
+				entry("", "", "Post-Id = a\nTitle: A"),
+			},
+			methods: map[string]string{"a": MatchPattern},
+			sources: map[string]string{"a": "Post-Id = a\nTitle: A"},
+		},
+		{
+			name:     "unmatched and dropped are reported separately",
+			patterns: DefaultEntryIDPatterns,
+			entries: []anpmodels.EntrySummary{
+				entry("a", "", ""),
+				entry("", "", "ID: c\nTitle: C"),
+				entry("", "", "Title: D"),
+			},
+			methods:    map[string]string{},
+			sources:    map[string]string{},
+			unmatched:  []string{"a"},
+			dropped:    []string{"c"},
+			unknownRaw: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewPatternEntryMatcher(tt.patterns)
+			if err != nil {
+				t.Fatalf("NewPatternEntryMatcher() error = %v", err)
+			}
+			report := matcher.Match(tt.entries)
+			if !reflect.DeepEqual(report.Methods, tt.methods) {
+				t.Errorf("Methods = %v, want %v", report.Methods, tt.methods)
+			}
+			if !reflect.DeepEqual(report.Sources, tt.sources) {
+				t.Errorf("Sources = %v, want %v", report.Sources, tt.sources)
+			}
+			if !reflect.DeepEqual(report.Unmatched, tt.unmatched) {
+				t.Errorf("Unmatched = %v, want %v", report.Unmatched, tt.unmatched)
+			}
+			if !reflect.DeepEqual(report.Dropped, tt.dropped) {
+				t.Errorf("Dropped = %v, want %v", report.Dropped, tt.dropped)
+			}
+			if report.UnidentifiedInputs != tt.unknownRaw {
+				t.Errorf("UnidentifiedInputs = %d, want %d", report.UnidentifiedInputs, tt.unknownRaw)
+			}
+		})
+	}
+}
+
+func TestNewPatternEntryMatcherRejectsPatternWithoutGroup(t *testing.T) {
+	if _, err := NewPatternEntryMatcher([]string{`^ID:\s*\S+`}); err == nil {
+		t.Error("expected an error for a pattern without a capture group")
+	}
+}
@@ -0,0 +1,121 @@
+package benchmark
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// MetricsPoint is a single benchmark's contribution to a persona's metrics history
+type MetricsPoint struct {
+	BenchmarkID            string    `json:"benchmarkId"`
+	RunID                  string    `json:"runId"`
+	Timestamp              time.Time `json:"timestamp"`
+	QualityScore           float64   `json:"qualityScore"`
+	RelevanceAccuracy      float64   `json:"relevanceAccuracy"`
+	MeanSourceSimilarity   float64   `json:"meanSourceSimilarity,omitempty"`
+	MeanCrossRunSimilarity float64   `json:"meanCrossRunSimilarity,omitempty"`
+	TotalTokens            int64     `json:"totalTokens,omitempty"`
+	EstimatedCost          float64   `json:"estimatedCost,omitempty"`
+}
+
+// personaBenchmarks returns the successful benchmarks for a persona within [from, to], oldest first.
+// A zero from or to leaves that end of the range open.
+func personaBenchmarks(personaName string, from, to time.Time) ([]models.BenchmarkResults, error) {
+	all, err := storage.ListBenchmarkResults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list benchmark results: %w", err)
+	}
+
+	var matching []models.BenchmarkResults
+	for _, results := range all {
+		if results.PersonaName != personaName || results.FailureReason != "" {
+			continue
+		}
+		if !from.IsZero() && results.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && results.Timestamp.After(to) {
+			continue
+		}
+		matching = append(matching, results)
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Timestamp.Before(matching[j].Timestamp)
+	})
+	return matching, nil
+}
+
+// PersonaMetrics aggregates benchmark results for a persona over a time range
+func (bs *BenchmarkService) PersonaMetrics(personaName string, from, to time.Time) (*models.PersonaMetrics, error) {
+	benchmarks, err := personaBenchmarks(personaName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var qualityTotal, relevanceTotal, sourceTotal, crossRunTotal float64
+	var semanticCount, crossRunCount int
+	var usageTotal models.TokenUsage
+	var usageCount int
+	history := make([]MetricsPoint, 0, len(benchmarks))
+	for _, results := range benchmarks {
+		qualityTotal += results.QualityScore
+		relevanceTotal += results.RelevanceAccuracy
+
+		point := MetricsPoint{
+			BenchmarkID:       results.BenchmarkID,
+			RunID:             results.RunID,
+			Timestamp:         results.Timestamp,
+			QualityScore:      results.QualityScore,
+			RelevanceAccuracy: results.RelevanceAccuracy,
+		}
+		if results.SemanticSummary != nil {
+			point.MeanSourceSimilarity = results.SemanticSummary.MeanSourceSimilarity
+			sourceTotal += results.SemanticSummary.MeanSourceSimilarity
+			semanticCount++
+			if results.SemanticSummary.CrossRunItems > 0 {
+				// A falling cross-run similarity means summaries of the same items are drifting
+				point.MeanCrossRunSimilarity = results.SemanticSummary.MeanCrossRunSimilarity
+				crossRunTotal += results.SemanticSummary.MeanCrossRunSimilarity
+				crossRunCount++
+			}
+		}
+		if results.Usage != nil {
+			point.TotalTokens = results.Usage.Total.TotalTokens
+			point.EstimatedCost = results.Usage.Total.EstimatedCost
+			addUsage(&usageTotal, results.Usage.Total)
+			usageCount++
+		}
+		history = append(history, point)
+	}
+
+	data := map[string]interface{}{
+		"benchmarksAnalyzed": len(benchmarks),
+		"history":            history,
+	}
+	if len(benchmarks) > 0 {
+		data["averageQualityScore"] = qualityTotal / float64(len(benchmarks))
+		data["averageRelevanceAccuracy"] = relevanceTotal / float64(len(benchmarks))
+	}
+	if semanticCount > 0 {
+		data["averageSourceSimilarity"] = sourceTotal / float64(semanticCount)
+	}
+	if crossRunCount > 0 {
+		data["averageCrossRunSimilarity"] = crossRunTotal / float64(crossRunCount)
+	}
+	if usageCount > 0 {
+		// Benchmarks from before usage was recorded are left out of the cost averages
+		data["usage"] = usageTotal
+		data["averageTokensPerBenchmark"] = float64(usageTotal.TotalTokens) / float64(usageCount)
+		data["averageCostPerBenchmark"] = usageTotal.EstimatedCost / float64(usageCount)
+	}
+
+	return &models.PersonaMetrics{
+		PersonaName: personaName,
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package benchmark
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+func TestPersonaMetrics(t *testing.T) {
+	initTestDB(t)
+
+	now := time.Now()
+	benchmarks := []models.BenchmarkResults{
+		{BenchmarkID: "newer", PersonaName: "alpha", QualityScore: 80, RelevanceAccuracy: 0.9, Timestamp: now,
+			SemanticSummary: &models.SemanticSummary{ItemsCompared: 2, MeanSourceSimilarity: 0.8, CrossRunItems: 1, MeanCrossRunSimilarity: 0.7},
+			Usage:           &models.BenchmarkUsage{Total: models.TokenUsage{Requests: 2, TotalTokens: 300, EstimatedCost: 0.03}}},
+		{BenchmarkID: "older", PersonaName: "alpha", QualityScore: 60, RelevanceAccuracy: 0.7, Timestamp: now.Add(-time.Hour),
+			SemanticSummary: &models.SemanticSummary{ItemsCompared: 2, MeanSourceSimilarity: 0.6}},
+		{BenchmarkID: "ancient", PersonaName: "alpha", QualityScore: 10, Timestamp: now.Add(-48 * time.Hour)},
+		{BenchmarkID: "failed", PersonaName: "alpha", FailureReason: "boom", Timestamp: now},
+		{BenchmarkID: "other", PersonaName: "beta", QualityScore: 100, Timestamp: now},
+	}
+	for _, results := range benchmarks {
+		if err := storage.SaveBenchmarkResults(results.BenchmarkID, results); err != nil {
+			t.Fatalf("SaveBenchmarkResults(%s) error = %v", results.BenchmarkID, err)
+		}
+	}
+
+	bs := &BenchmarkService{}
+	metrics, err := bs.PersonaMetrics("alpha", now.Add(-24*time.Hour), time.Time{})
+	if err != nil {
+		t.Fatalf("PersonaMetrics() error = %v", err)
+	}
+	data := metrics.Data
+
+	if data["benchmarksAnalyzed"] != 2 {
+		t.Fatalf("benchmarksAnalyzed = %v, want the two successful alpha benchmarks in range", data["benchmarksAnalyzed"])
+	}
+	history := data["history"].([]MetricsPoint)
+	if history[0].BenchmarkID != "older" || history[1].BenchmarkID != "newer" {
+		t.Errorf("history = %+v, want oldest first", history)
+	}
+	if history[1].MeanCrossRunSimilarity != 0.7 || history[0].MeanCrossRunSimilarity != 0 || history[1].TotalTokens != 300 {
+		t.Errorf("history = %+v", history)
+	}
+
+	expected := map[string]float64{
+		"averageQualityScore":       70,
+		"averageRelevanceAccuracy":  0.8,
+		"averageSourceSimilarity":   0.7,
+		"averageCrossRunSimilarity": 0.7,
+		"averageTokensPerBenchmark": 300,
+		"averageCostPerBenchmark":   0.03,
+	}
+	for key, want := range expected {
+		got, ok := data[key].(float64)
+		if !ok || math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", key, data[key], want)
+		}
+	}
+
+	empty, err := bs.PersonaMetrics("nobody", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("PersonaMetrics() error = %v", err)
+	}
+	if _, ok := empty.Data["averageQualityScore"]; ok || empty.Data["benchmarksAnalyzed"] != 0 {
+		t.Errorf("metrics without benchmarks = %+v", empty.Data)
+	}
+}
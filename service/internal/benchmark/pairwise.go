@@ -0,0 +1,331 @@
+package benchmark
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/openai"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+	"github.com/google/uuid"
+)
+
+// ErrPersonaMismatch is returned when comparing runs produced for different personas
+var ErrPersonaMismatch = errors.New("runs belong to different personas")
+
+const (
+	// PairwiseHead means the head run's summary was better
+	PairwiseHead = "head"
+	// PairwiseBase means the base run's summary was better
+	PairwiseBase = "base"
+	// PairwiseTie means neither summary was clearly better
+	PairwiseTie = "tie"
+)
+
+const pairwisePrompt = `You are an expert in evaluating AI-generated content. Your task is to compare two summaries of the same post and decide which one summarizes and analyzes the source material better.
+
+The persona is {{.PersonaIdentity}}
+
+The persona's focus areas are:
+{{range .FocusAreas}}* {{.}}
+{{end}}
+
+Compare the summaries on the following criteria:
+   - Comprehensiveness: Does it capture all key details?
+   - Technical Accuracy: If technical details are provided, are they accurate?
+   - Clarity: Is the information presented in a clear, well-structured manner?
+   - Comment Integration: Are community discussions and feedback well-analyzed?
+
+Judge only the content. The order in which the summaries are presented is arbitrary and must not influence your decision. Answer "tie" if neither summary is clearly better.
+
+Respond with a JSON object containing:
+{
+  "winner": string,  // One of: "A", "B", "tie"
+  "explanation": string  // Explanation of the decision
+}`
+
+// PairwiseResult represents the structure of a pairwise comparison response
+type PairwiseResult struct {
+	Winner      string `json:"winner"`
+	Explanation string `json:"explanation"`
+}
+
+// PairwiseResultSchema defines the JSON schema for the pairwise comparison result
+var PairwiseResultSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"winner": map[string]interface{}{
+			"type":        "string",
+			"description": "Which summary is better",
+			"enum":        []string{"A", "B", "tie"},
+		},
+		"explanation": map[string]interface{}{
+			"type":        "string",
+			"description": "Explanation of the decision",
+		},
+	},
+	"required":             []string{"winner", "explanation"},
+	"additionalProperties": false,
+}
+
+// CreatePairwiseComparison starts a pairwise comparison of the summaries in two runs
+func (bs *BenchmarkService) CreatePairwiseComparison(baseRunID, headRunID string) (*models.BenchmarkResponse, error) {
+	baseRun, err := storage.GetRunData(baseRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base run data: %w", err)
+	}
+	headRun, err := storage.GetRunData(headRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head run data: %w", err)
+	}
+	if baseRun.Persona.Name != headRun.Persona.Name {
+		return nil, fmt.Errorf("%w: %q and %q", ErrPersonaMismatch, baseRun.Persona.Name, headRun.Persona.Name)
+	}
+
+	comparisonID := uuid.NewString()
+
+	response := &models.BenchmarkResponse{
+		ID:                      comparisonID,
+		Status:                  "queued",
+		Message:                 "Pairwise comparison queued for processing",
+		EstimatedCompletionTime: time.Now().Add(10 * time.Minute), // Two judge calls per item per judge
+	}
+
+	go bs.processPairwise(comparisonID, baseRun, headRun)
+
+	return response, nil
+}
+
+// processPairwise judges every item present in both runs
+func (bs *BenchmarkService) processPairwise(comparisonID string, baseRun, headRun *models.PersistedRunData) {
+	log.Printf("Starting pairwise comparison %s: base run %s, head run %s", comparisonID, baseRun.RunID, headRun.RunID)
+
+	results := &models.PairwiseResults{
+		ComparisonID: comparisonID,
+		BaseRunID:    baseRun.RunID,
+		HeadRunID:    headRun.RunID,
+		PersonaName:  headRun.Persona.Name,
+		Timestamp:    time.Now(),
+		Verdicts:     make(map[string]models.PairwiseVerdict),
+		Judging:      bs.judgeSettings(),
+	}
+
+	tmpl, err := template.New("pairwise").Parse(pairwisePrompt)
+	if err != nil {
+		bs.savePairwiseError(results, "Failed to parse pairwise prompt", err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, headRun.Persona); err != nil {
+		bs.savePairwiseError(results, "Failed to execute pairwise prompt", err)
+		return
+	}
+	systemPrompt := buf.String()
+
+	baseReport := bs.matcher.Match(baseRun.EntrySummaries)
+	headReport := bs.matcher.Match(headRun.EntrySummaries)
+	baseItems, headItems := baseReport.Items, headReport.Items
+	headSources, baseSources := headReport.Sources, baseReport.Sources
+
+	for id := range baseItems {
+		if _, ok := headItems[id]; !ok {
+			results.OnlyInBase = append(results.OnlyInBase, id)
+		}
+	}
+
+	ids := make([]string, 0, len(headItems))
+	for id := range headItems {
+		if _, ok := baseItems[id]; !ok {
+			results.OnlyInHead = append(results.OnlyInHead, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sort.Strings(results.OnlyInBase)
+	sort.Strings(results.OnlyInHead)
+
+	panel := bs.newJudgePanel()
+	for _, id := range ids {
+		source, ok := headSources[id]
+		if !ok {
+			source, ok = baseSources[id]
+		}
+		if !ok {
+			log.Printf("Warning: No matching raw input for pairwise item ID: %s", id)
+			results.FailedItems = append(results.FailedItems, id)
+			continue
+		}
+
+		log.Printf("Comparing entry (ID: %s) with %d judge(s)...", id, len(panel))
+		verdict, ok := bs.comparePair(panel, systemPrompt, source, baseItems[id], headItems[id])
+		if !ok {
+			log.Printf("Error comparing entry %s: no judge produced a verdict", id)
+			results.FailedItems = append(results.FailedItems, id)
+			continue
+		}
+
+		results.Verdicts[id] = verdict
+		results.TotalItems++
+		switch verdict.Winner {
+		case PairwiseHead:
+			results.Wins++
+		case PairwiseBase:
+			results.Losses++
+		default:
+			results.Ties++
+		}
+	}
+
+	if results.TotalItems > 0 {
+		total := float64(results.TotalItems)
+		results.WinRate = float64(results.Wins) / total
+		results.TieRate = float64(results.Ties) / total
+		results.LossRate = float64(results.Losses) / total
+	}
+
+	if err := storage.SavePairwiseResults(comparisonID, *results); err != nil {
+		log.Printf("Error saving pairwise results: %v", err)
+		return
+	}
+
+	log.Printf("Pairwise comparison %s completed: %d wins, %d ties, %d losses for head run %s",
+		comparisonID, results.Wins, results.Ties, results.Losses, headRun.RunID)
+}
+
+// comparePair asks every judge for its preference and combines the votes by plurality.
+// It returns false if no judge produced a usable verdict.
+func (bs *BenchmarkService) comparePair(panel []judge, systemPrompt, source string, baseItem, headItem anpmodels.Item) (models.PairwiseVerdict, bool) {
+	verdict := models.PairwiseVerdict{Votes: make([]models.PairwiseJudgeVote, 0, len(panel))}
+	counts := make(map[string]int)
+
+	for _, j := range panel {
+		vote := bs.pairwiseVote(j, systemPrompt, source, baseItem, headItem)
+		verdict.Votes = append(verdict.Votes, vote)
+		if vote.Error != "" {
+			log.Printf("Judge %s failed: %s", vote.Judge, vote.Error)
+			continue
+		}
+		counts[vote.Winner]++
+		if verdict.Explanation == "" {
+			verdict.Explanation = vote.Explanation
+		}
+	}
+
+	if len(counts) == 0 {
+		return verdict, false
+	}
+
+	verdict.Winner = PairwiseTie
+	if counts[PairwiseHead] > counts[PairwiseBase] && counts[PairwiseHead] > counts[PairwiseTie] {
+		verdict.Winner = PairwiseHead
+	} else if counts[PairwiseBase] > counts[PairwiseHead] && counts[PairwiseBase] > counts[PairwiseTie] {
+		verdict.Winner = PairwiseBase
+	}
+	return verdict, true
+}
+
+// pairwiseVote asks a judge to compare the summaries in both orderings.
+// If the orderings disagree the vote is a tie, cancelling position bias.
+func (bs *BenchmarkService) pairwiseVote(j judge, systemPrompt, source string, baseItem, headItem anpmodels.Item) models.PairwiseJudgeVote {
+	vote := models.PairwiseJudgeVote{Judge: j.config.Name}
+
+	baseSummary := bs.formatSummary(baseItem)
+	headSummary := bs.formatSummary(headItem)
+
+	// Ordering 1: A is head, B is base
+	first, err := bs.requestPairwise(j, systemPrompt, source, headSummary, baseSummary)
+	if err != nil {
+		vote.Error = err.Error()
+		return vote
+	}
+	firstWinner := mapPairwiseWinner(first.Winner, PairwiseHead, PairwiseBase)
+
+	// Ordering 2: A is base, B is head
+	second, err := bs.requestPairwise(j, systemPrompt, source, baseSummary, headSummary)
+	if err != nil {
+		vote.Error = err.Error()
+		return vote
+	}
+	secondWinner := mapPairwiseWinner(second.Winner, PairwiseBase, PairwiseHead)
+
+	vote.Explanation = first.Explanation
+	vote.OrderConsistent = firstWinner == secondWinner
+	if vote.OrderConsistent {
+		vote.Winner = firstWinner
+	} else {
+		vote.Winner = PairwiseTie
+	}
+	return vote
+}
+
+// requestPairwise performs a single pairwise judge call and parses the response
+func (bs *BenchmarkService) requestPairwise(j judge, systemPrompt, source, summaryA, summaryB string) (PairwiseResult, error) {
+	var result PairwiseResult
+
+	input := fmt.Sprintf("Source Material:\n%s\n\nSummary A:\n%s\n\nSummary B:\n%s\n", source, summaryA, summaryB)
+	schemaParams := &openai.SchemaParameters{
+		Schema:      PairwiseResultSchema,
+		Name:        "pairwise_comparison",
+		Description: "an object representing which of two summaries is better",
+	}
+
+	resultChan := make(chan openai.CompletionResult, 1)
+	j.client.ChatCompletion(systemPrompt, []string{input}, []string{}, schemaParams, 0.0, 0, resultChan)
+	response := <-resultChan
+	if response.Err != nil {
+		return result, fmt.Errorf("%w: %w", errJudgeCall, response.Err)
+	}
+
+	if err := json.Unmarshal([]byte(j.client.PreprocessJSON(response.Value)), &result); err != nil {
+		return result, fmt.Errorf("%w: %w", errJudgeResponse, err)
+	}
+	if result.Winner != "A" && result.Winner != "B" && result.Winner != "tie" {
+		return result, fmt.Errorf("%w: unknown winner %q", errJudgeResponse, result.Winner)
+	}
+	return result, nil
+}
+
+// mapPairwiseWinner translates an A/B answer back to the run that was in that position
+func mapPairwiseWinner(winner, runA, runB string) string {
+	switch winner {
+	case "A":
+		return runA
+	case "B":
+		return runB
+	default:
+		return PairwiseTie
+	}
+}
+
+// itemsByID maps each processed item in a run to its ID
+func itemsByID(entrySummaries []anpmodels.EntrySummary) map[string]anpmodels.Item {
+	items := make(map[string]anpmodels.Item)
+	for _, summary := range entrySummaries {
+		if summary.Results.ID != "" {
+			items[summary.Results.ID] = summary.Results
+		}
+	}
+	return items
+}
+
+// savePairwiseError saves pairwise comparison error information
+func (bs *BenchmarkService) savePairwiseError(results *models.PairwiseResults, message string, err error) {
+	log.Printf("%s: %v", message, err)
+	results.FailureReason = fmt.Sprintf("%s: %v", message, err)
+	if saveErr := storage.SavePairwiseResults(results.ComparisonID, *results); saveErr != nil {
+		log.Printf("Failed to save pairwise error: %v", saveErr)
+	}
+}
+
+// GetPairwiseResults retrieves pairwise comparison results by comparison ID
+func (bs *BenchmarkService) GetPairwiseResults(comparisonID string) (*models.PairwiseResults, error) {
+	return storage.GetPairwiseResults(comparisonID)
+}
@@ -0,0 +1,167 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+var (
+	pairwiseBaseItem = anpmodels.Item{ID: "item", Summary: "base summary"}
+	pairwiseHeadItem = anpmodels.Item{ID: "item", Summary: "head summary"}
+)
+
+// pairwiseJudge returns a judge that answers every comparison with pick, given the summary in position A
+func pairwiseJudge(name string, pick func(summaryA string) string) judge {
+	return judge{
+		config: internal.JudgeConfig{Name: name},
+		client: &fakeJudgeClient{respond: func(userPrompts []string) (string, error) {
+			input := userPrompts[0]
+			summaryA := input[strings.Index(input, "Summary A:"):strings.Index(input, "Summary B:")]
+			return fmt.Sprintf(`{"winner": %q, "explanation": "%s judged"}`, pick(summaryA), name), nil
+		}},
+	}
+}
+
+// prefers picks whichever position holds the wanted summary
+func prefers(summary string) func(string) string {
+	return func(summaryA string) string {
+		if strings.Contains(summaryA, summary) {
+			return "A"
+		}
+		return "B"
+	}
+}
+
+// always picks the same answer regardless of position
+func always(answer string) func(string) string {
+	return func(string) string { return answer }
+}
+
+func TestMapPairwiseWinner(t *testing.T) {
+	tests := []struct {
+		winner string
+		want   string
+	}{
+		{winner: "A", want: PairwiseHead},
+		{winner: "B", want: PairwiseBase},
+		{winner: "tie", want: PairwiseTie},
+		{winner: "", want: PairwiseTie},
+	}
+	for _, tt := range tests {
+		if got := mapPairwiseWinner(tt.winner, PairwiseHead, PairwiseBase); got != tt.want {
+			t.Errorf("mapPairwiseWinner(%q) = %q, want %q", tt.winner, got, tt.want)
+		}
+	}
+}
+
+func TestPairwiseVote(t *testing.T) {
+	tests := []struct {
+		name           string
+		judge          judge
+		wantWinner     string
+		wantConsistent bool
+		wantErr        error
+	}{
+		{name: "prefers head in both orders", judge: pairwiseJudge("j", prefers("head summary")), wantWinner: PairwiseHead, wantConsistent: true},
+		{name: "prefers base in both orders", judge: pairwiseJudge("j", prefers("base summary")), wantWinner: PairwiseBase, wantConsistent: true},
+		{name: "tie in both orders", judge: pairwiseJudge("j", always("tie")), wantWinner: PairwiseTie, wantConsistent: true},
+		// Always choosing the first summary is position bias, so the orders disagree
+		{name: "position bias", judge: pairwiseJudge("j", always("A")), wantWinner: PairwiseTie},
+		{name: "unknown winner", judge: pairwiseJudge("j", always("C")), wantErr: errJudgeResponse},
+		{
+			name: "failed call",
+			judge: judge{client: &fakeJudgeClient{respond: func([]string) (string, error) {
+				return "", errors.New("connection refused")
+			}}},
+			wantErr: errJudgeCall,
+		},
+	}
+	bs := &BenchmarkService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vote := bs.pairwiseVote(tt.judge, "system", "source", pairwiseBaseItem, pairwiseHeadItem)
+			if tt.wantErr != nil {
+				if vote.Error == "" {
+					t.Fatalf("vote = %+v, want an error", vote)
+				}
+				_, err := bs.requestPairwise(tt.judge, "system", "source", "A", "B")
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("requestPairwise() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if vote.Error != "" {
+				t.Fatalf("vote error = %s", vote.Error)
+			}
+			if vote.Winner != tt.wantWinner || vote.OrderConsistent != tt.wantConsistent {
+				t.Errorf("vote = %+v, want winner %s and consistent %v", vote, tt.wantWinner, tt.wantConsistent)
+			}
+		})
+	}
+}
+
+func TestComparePair(t *testing.T) {
+	failing := judge{
+		config: internal.JudgeConfig{Name: "down"},
+		client: &fakeJudgeClient{respond: func([]string) (string, error) { return "", errors.New("connection refused") }},
+	}
+
+	tests := []struct {
+		name       string
+		panel      []judge
+		wantWinner string
+		wantOK     bool
+	}{
+		{
+			name:       "majority for head",
+			panel:      []judge{pairwiseJudge("a", prefers("head summary")), pairwiseJudge("b", prefers("head summary")), pairwiseJudge("c", prefers("base summary"))},
+			wantWinner: PairwiseHead,
+			wantOK:     true,
+		},
+		{
+			name:       "plurality for base over ties",
+			panel:      []judge{pairwiseJudge("a", prefers("base summary")), pairwiseJudge("b", prefers("base summary")), pairwiseJudge("c", always("A")), failing},
+			wantWinner: PairwiseBase,
+			wantOK:     true,
+		},
+		{
+			name:       "split vote is a tie",
+			panel:      []judge{pairwiseJudge("a", prefers("head summary")), pairwiseJudge("b", prefers("base summary"))},
+			wantWinner: PairwiseTie,
+			wantOK:     true,
+		},
+		{
+			name:       "ties outnumber head",
+			panel:      []judge{pairwiseJudge("a", prefers("head summary")), pairwiseJudge("b", always("tie")), pairwiseJudge("c", always("tie"))},
+			wantWinner: PairwiseTie,
+			wantOK:     true,
+		},
+		{
+			name:  "every judge failed",
+			panel: []judge{failing},
+		},
+	}
+	bs := &BenchmarkService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, ok := bs.comparePair(tt.panel, "system", "source", pairwiseBaseItem, pairwiseHeadItem)
+			if ok != tt.wantOK {
+				t.Fatalf("comparePair() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if len(verdict.Votes) != len(tt.panel) {
+				t.Errorf("votes = %d, want one per judge", len(verdict.Votes))
+			}
+			if ok && verdict.Winner != tt.wantWinner {
+				t.Errorf("winner = %s, want %s", verdict.Winner, tt.wantWinner)
+			}
+			if ok && verdict.Explanation != tt.panel[0].config.Name+" judged" {
+				t.Errorf("explanation = %q, want the first judge's", verdict.Explanation)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/openai"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+var (
+	// ErrRubricChanged is returned when a benchmark was judged with a rubric this build no longer has
+	ErrRubricChanged = errors.New("benchmark was judged with a different rubric version")
+	// ErrNotReevaluable is returned for benchmarks or items that cannot be judged again
+	ErrNotReevaluable = errors.New("item cannot be re-evaluated")
+	// ErrJudgeUnavailable is returned when a judge from the original panel is no longer configured
+	ErrJudgeUnavailable = errors.New("original judge is no longer configured")
+)
+
+// resultsMu serialises read-modify-write updates of stored benchmark results
+var resultsMu sync.Mutex
+
+// reevaluation re-judges items of a stored benchmark with the panel and rubric it was created with
+type reevaluation struct {
+	judging    *BenchmarkService // Copy of the service configured with the benchmark's judge settings
+	results    *models.BenchmarkResults
+	report     MatchReport
+	evalCtx    evaluationContext
+	embedModel string // Empty when the benchmark did not compute semantic similarity
+}
+
+// ReevaluateItem judges a single item of a benchmark again and stores the new verdict,
+// keeping the previous one in the item's history
+func (bs *BenchmarkService) ReevaluateItem(benchmarkID, itemID string) (*models.EvaluationResult, error) {
+	re, err := bs.prepareReevaluation(benchmarkID)
+	if err != nil {
+		return nil, err
+	}
+
+	eval, err := re.evaluate(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := re.record(benchmarkID, itemID, eval)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// RetryFailedItems re-judges every errored item of a benchmark in the background
+func (bs *BenchmarkService) RetryFailedItems(benchmarkID string) (*models.BenchmarkResponse, error) {
+	re, err := bs.prepareReevaluation(benchmarkID)
+	if err != nil {
+		return nil, err
+	}
+
+	itemIDs := re.results.ErroredItems
+	if len(itemIDs) == 0 {
+		return &models.BenchmarkResponse{
+			ID:      benchmarkID,
+			Status:  "completed",
+			Message: "Benchmark has no failed items to retry",
+		}, nil
+	}
+
+	go func() {
+		log.Printf("Retrying %d failed item(s) for benchmark ID: %s", len(itemIDs), benchmarkID)
+		for _, itemID := range itemIDs {
+			eval, err := re.evaluate(itemID)
+			if err != nil {
+				log.Printf("Error re-evaluating item %s of benchmark %s: %v", itemID, benchmarkID, err)
+				continue
+			}
+			// Saving after each item keeps progress if the retry is interrupted
+			if _, err := re.record(benchmarkID, itemID, eval); err != nil {
+				log.Printf("Error saving re-evaluation of item %s of benchmark %s: %v", itemID, benchmarkID, err)
+			}
+		}
+		log.Printf("Finished retrying failed items for benchmark ID: %s", benchmarkID)
+	}()
+
+	return &models.BenchmarkResponse{
+		ID:                      benchmarkID,
+		Status:                  "queued",
+		Message:                 fmt.Sprintf("Retry of %d failed item(s) queued for processing", len(itemIDs)),
+		EstimatedCompletionTime: time.Now().Add(time.Duration(len(itemIDs)) * 10 * time.Second), // Rough estimate
+	}, nil
+}
+
+// prepareReevaluation loads a benchmark and its run and rebuilds the judging setup it was created with
+func (bs *BenchmarkService) prepareReevaluation(benchmarkID string) (*reevaluation, error) {
+	results, err := storage.GetBenchmarkResultsByBenchmarkID(benchmarkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benchmark results: %w", err)
+	}
+	if results.FailureReason != "" {
+		return nil, fmt.Errorf("%w: benchmark failed: %s", ErrNotReevaluable, results.FailureReason)
+	}
+	if results.Judging != nil && results.Judging.RubricVersion != "" && results.Judging.RubricVersion != evaluationRubricVersion {
+		return nil, fmt.Errorf("%w: benchmark used rubric %s, current rubric is %s",
+			ErrRubricChanged, results.Judging.RubricVersion, evaluationRubricVersion)
+	}
+
+	runData, err := storage.GetRunData(results.RunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run data: %w", err)
+	}
+
+	systemPrompt, err := renderEvaluationPrompt(runData)
+	if err != nil {
+		return nil, err
+	}
+
+	judging := *bs
+	if settings := results.Judging; settings != nil {
+		judging.aggregation = settings.Aggregation
+		if settings.Samples > 0 {
+			judging.samples = settings.Samples
+		}
+		if settings.Temperature > 0 {
+			judging.sampleTemperature = settings.Temperature
+		}
+		judges, err := bs.originalJudges(settings.Judges)
+		if err != nil {
+			return nil, err
+		}
+		judging.judges = judges
+	}
+
+	// The judge cache is left unset: re-evaluating an item should always get a fresh verdict
+	re := &reevaluation{
+		judging: &judging,
+		results: results,
+		report:  bs.matcher.Match(runData.EntrySummaries),
+		evalCtx: evaluationContext{
+			panel:        judging.newJudgePanel(),
+			systemPrompt: systemPrompt,
+		},
+	}
+
+	// Only compute semantic similarity if the benchmark did, and with the same embedding model
+	if summary := results.SemanticSummary; summary != nil {
+		re.embedModel = summary.EmbeddingModel
+		re.evalCtx.embedder = openai.New(bs.embeddingURL, bs.embeddingAPIKey, summary.EmbeddingModel)
+		if summary.PreviousRunID != "" {
+			previous, err := storage.GetRunData(summary.PreviousRunID)
+			if err != nil {
+				log.Printf("Error getting previous run %s, cross-run similarity will be skipped: %v", summary.PreviousRunID, err)
+			} else {
+				re.evalCtx.previousRunID = summary.PreviousRunID
+				re.evalCtx.previousItems = itemsByID(previous.EntrySummaries)
+			}
+		}
+	}
+
+	return re, nil
+}
+
+// originalJudges resolves the judges a benchmark was run with against the configured judges.
+// Credentials are never persisted, so each judge must still be configured under the same name,
+// or another configured judge must share its URL.
+func (bs *BenchmarkService) originalJudges(infos []models.JudgeInfo) ([]internal.JudgeConfig, error) {
+	if len(infos) == 0 {
+		return bs.judges, nil
+	}
+
+	judges := make([]internal.JudgeConfig, 0, len(infos))
+	for _, info := range infos {
+		cfg := internal.JudgeConfig{Name: info.Name, URL: info.URL, Model: info.Model}
+		found := false
+		for _, configured := range bs.judges {
+			if configured.Name == info.Name || configured.URL == info.URL {
+				cfg.APIKey = configured.APIKey
+				found = true
+				if configured.Name == info.Name {
+					break
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s (%s)", ErrJudgeUnavailable, info.Name, info.Model)
+		}
+		judges = append(judges, cfg)
+	}
+	return judges, nil
+}
+
+// evaluate judges a single item again
+func (re *reevaluation) evaluate(itemID string) (models.EvaluationResult, error) {
+	existing, ok := re.results.DetailedEvaluations[itemID]
+	if !ok {
+		return models.EvaluationResult{}, fmt.Errorf("item '%s' not found in benchmark %s", itemID, re.results.BenchmarkID)
+	}
+	if existing.Status == models.ItemStatusMissingFromOutput {
+		return models.EvaluationResult{}, fmt.Errorf("%w: item %s is missing from the processed results", ErrNotReevaluable, itemID)
+	}
+
+	item, ok := re.report.Items[itemID]
+	if !ok {
+		return models.EvaluationResult{}, fmt.Errorf("%w: item %s is no longer in the run data", ErrNotReevaluable, itemID)
+	}
+
+	rawInput, ok := re.report.Sources[itemID]
+	if !ok {
+		return models.EvaluationResult{
+			Status:      models.ItemStatusNoSource,
+			Error:       "No matching raw input was found for this item",
+			EvaluatedAt: time.Now(),
+		}, nil
+	}
+
+	log.Printf("Re-evaluating entry (ID: %s) of benchmark %s...", itemID, re.results.BenchmarkID)
+	eval := re.judging.evaluateItem(re.evalCtx, itemID, item, rawInput)
+	eval.MatchMethod = re.report.Methods[itemID]
+	return eval, nil
+}
+
+// record stores a new verdict for an item and recomputes the benchmark aggregates.
+// The stored results are reloaded under the lock so concurrent re-evaluations are not lost.
+func (re *reevaluation) record(benchmarkID, itemID string, eval models.EvaluationResult) (models.EvaluationResult, error) {
+	resultsMu.Lock()
+	defer resultsMu.Unlock()
+
+	results, err := storage.GetBenchmarkResultsByBenchmarkID(benchmarkID)
+	if err != nil {
+		return eval, fmt.Errorf("failed to get benchmark results: %w", err)
+	}
+
+	// History is kept flat on the latest verdict, oldest first
+	if previous, ok := results.DetailedEvaluations[itemID]; ok {
+		history := previous.PreviousVerdicts
+		previous.PreviousVerdicts = nil
+		eval.PreviousVerdicts = append(history, previous)
+	}
+	results.DetailedEvaluations[itemID] = eval
+
+	results.UnmatchedItems = nil
+	for id, e := range results.DetailedEvaluations {
+		if e.Status == models.ItemStatusNoSource {
+			results.UnmatchedItems = append(results.UnmatchedItems, id)
+		}
+	}
+	sort.Strings(results.UnmatchedItems)
+
+	previousRunID := ""
+	if results.SemanticSummary != nil {
+		previousRunID = results.SemanticSummary.PreviousRunID
+	}
+	computeAggregates(results)
+	if results.Status == models.BenchmarkStatusBudgetExceeded && len(results.SkippedItems) == 0 {
+		// Every skipped item has since been evaluated individually
+		results.Status = models.BenchmarkStatusCompleted
+	}
+	summariseResults(results, len(re.evalCtx.panel), previousRunID, re.embedModel)
+	results.Usage = summariseUsage(results, re.judging.prices)
+
+	if err := storage.SaveBenchmarkResults(benchmarkID, *results); err != nil {
+		return eval, fmt.Errorf("failed to save benchmark results: %w", err)
+	}
+
+	log.Printf("Re-evaluated item %s of benchmark %s: status = %s, quality score now %.1f with %.1f%% coverage",
+		itemID, benchmarkID, eval.Status, results.QualityScore, results.Coverage)
+	return eval, nil
+}
@@ -0,0 +1,35 @@
+package benchmark
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestOriginalJudges(t *testing.T) {
+	bs := &BenchmarkService{judges: []internal.JudgeConfig{
+		{Name: "primary", URL: "http://a", APIKey: "key-a", Model: "gpt-4o"},
+		{Name: "secondary", URL: "http://b", APIKey: "key-b", Model: "claude"},
+	}}
+
+	judges, err := bs.originalJudges([]models.JudgeInfo{
+		{Name: "secondary", URL: "http://b", Model: "claude-old"},
+		{Name: "retired", URL: "http://a", Model: "gpt-4"},
+	})
+	if err != nil {
+		t.Fatalf("originalJudges() error = %v", err)
+	}
+	if judges[0].Model != "claude-old" || judges[0].APIKey != "key-b" {
+		t.Errorf("judges[0] = %+v, want original model with key-b", judges[0])
+	}
+	if judges[1].Model != "gpt-4" || judges[1].APIKey != "key-a" {
+		t.Errorf("judges[1] = %+v, want original model with key-a", judges[1])
+	}
+
+	_, err = bs.originalJudges([]models.JudgeInfo{{Name: "gone", URL: "http://c", Model: "x"}})
+	if !errors.Is(err, ErrJudgeUnavailable) {
+		t.Errorf("error = %v, want ErrJudgeUnavailable", err)
+	}
+}
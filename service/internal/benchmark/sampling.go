@@ -0,0 +1,139 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+const (
+	// StratifyByRelevance samples relevant and irrelevant items in proportion to the run
+	StratifyByRelevance = "isRelevant"
+
+	stratumRelevant   = "relevant"
+	stratumIrrelevant = "irrelevant"
+	stratumMissing    = "missing_from_output"
+)
+
+// ErrInvalidSampling is returned for sampling specs that cannot be applied
+var ErrInvalidSampling = errors.New("invalid sampling spec")
+
+// validateSampling checks a sampling spec from a request
+func validateSampling(spec *models.SamplingSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if (spec.Size > 0) == (spec.Percentage > 0) {
+		return fmt.Errorf("%w: exactly one of size and percentage must be set", ErrInvalidSampling)
+	}
+	if spec.Size < 0 || spec.Percentage < 0 || spec.Percentage > 100 {
+		return fmt.Errorf("%w: size must be positive and percentage between 0 and 100", ErrInvalidSampling)
+	}
+	if spec.StratifyBy != "" && spec.StratifyBy != StratifyByRelevance {
+		return fmt.Errorf("%w: stratifyBy must be %q", ErrInvalidSampling, StratifyByRelevance)
+	}
+	return nil
+}
+
+// selectSample picks the items to judge from the matched run. Items dropped by the processor
+// are part of the population, in their own stratum when stratifying, so the sample's share
+// of missing items matches the run's.
+func selectSample(spec models.SamplingSpec, report MatchReport) (map[string]bool, *models.SamplingInfo) {
+	if spec.Seed == 0 {
+		spec.Seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewPCG(uint64(spec.Seed), 0))
+
+	// Group the population into strata, in a stable order so a seed always gives the same sample
+	strata := make(map[string][]string)
+	stratumOf := func(id string) string {
+		if spec.StratifyBy == "" {
+			return "all"
+		}
+		item, ok := report.Items[id]
+		if !ok {
+			return stratumMissing
+		}
+		if item.IsRelevant {
+			return stratumRelevant
+		}
+		return stratumIrrelevant
+	}
+	population := append(append([]string{}, report.ItemOrder...), report.Dropped...)
+	for _, id := range population {
+		name := stratumOf(id)
+		strata[name] = append(strata[name], id)
+	}
+	names := make([]string, 0, len(strata))
+	for name := range strata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	target := spec.Size
+	if spec.Percentage > 0 {
+		target = int(math.Ceil(float64(len(population)) * spec.Percentage / 100))
+	}
+	target = min(target, len(population))
+
+	quotas := allocateProportionally(target, names, strata)
+
+	info := &models.SamplingInfo{
+		Spec:           spec,
+		PopulationSize: len(population),
+	}
+	selected := make(map[string]bool, target)
+	for _, name := range names {
+		ids := strata[name]
+		for _, i := range rng.Perm(len(ids))[:quotas[name]] {
+			selected[ids[i]] = true
+		}
+		if spec.StratifyBy != "" {
+			info.Strata = append(info.Strata, models.SamplingStratum{Name: name, Population: len(ids), Sampled: quotas[name]})
+		}
+	}
+	info.SampleSize = len(selected)
+	return selected, info
+}
+
+// allocateProportionally splits n across strata in proportion to their sizes,
+// giving leftover places to the largest remainders
+func allocateProportionally(n int, names []string, strata map[string][]string) map[string]int {
+	total := 0
+	for _, name := range names {
+		total += len(strata[name])
+	}
+	quotas := make(map[string]int, len(names))
+	if total == 0 {
+		return quotas
+	}
+
+	remainders := make(map[string]float64, len(names))
+	allocated := 0
+	for _, name := range names {
+		exact := float64(n) * float64(len(strata[name])) / float64(total)
+		quotas[name] = int(exact)
+		remainders[name] = exact - float64(quotas[name])
+		allocated += quotas[name]
+	}
+
+	byRemainder := append([]string{}, names...)
+	sort.SliceStable(byRemainder, func(i, j int) bool {
+		return remainders[byRemainder[i]] > remainders[byRemainder[j]]
+	})
+	for _, name := range byRemainder {
+		if allocated >= n {
+			break
+		}
+		if quotas[name] < len(strata[name]) {
+			quotas[name]++
+			allocated++
+		}
+	}
+	return quotas
+}
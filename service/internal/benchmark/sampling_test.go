@@ -0,0 +1,112 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+// sampleReport builds a match report with the given numbers of relevant, irrelevant and dropped items
+func sampleReport(relevant, irrelevant, dropped int) MatchReport {
+	report := MatchReport{Items: make(map[string]anpmodels.Item)}
+	add := func(prefix string, n int, isRelevant bool) {
+		for i := 0; i < n; i++ {
+			id := fmt.Sprintf("%s%d", prefix, i)
+			report.Items[id] = anpmodels.Item{ID: id, IsRelevant: isRelevant}
+			report.ItemOrder = append(report.ItemOrder, id)
+		}
+	}
+	add("r", relevant, true)
+	add("i", irrelevant, false)
+	for i := 0; i < dropped; i++ {
+		report.Dropped = append(report.Dropped, fmt.Sprintf("d%d", i))
+	}
+	return report
+}
+
+func TestValidateSampling(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *models.SamplingSpec
+		wantErr bool
+	}{
+		{name: "none", spec: nil},
+		{name: "size", spec: &models.SamplingSpec{Size: 10}},
+		{name: "percentage stratified", spec: &models.SamplingSpec{Percentage: 25, StratifyBy: StratifyByRelevance}},
+		{name: "neither", spec: &models.SamplingSpec{}, wantErr: true},
+		{name: "both", spec: &models.SamplingSpec{Size: 10, Percentage: 10}, wantErr: true},
+		{name: "percentage over 100", spec: &models.SamplingSpec{Percentage: 150}, wantErr: true},
+		{name: "unknown stratum", spec: &models.SamplingSpec{Size: 10, StratifyBy: "title"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSampling(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSampling() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSampling) {
+				t.Errorf("error %v does not wrap ErrInvalidSampling", err)
+			}
+		})
+	}
+}
+
+func TestSelectSampleIsReproducible(t *testing.T) {
+	report := sampleReport(30, 60, 10)
+	spec := models.SamplingSpec{Size: 20, Seed: 42}
+
+	first, info := selectSample(spec, report)
+	second, _ := selectSample(spec, report)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("the same seed produced different samples")
+	}
+	if len(first) != 20 || info.SampleSize != 20 || info.PopulationSize != 100 {
+		t.Errorf("sample size = %d, info = %+v, want 20 of 100", len(first), info)
+	}
+
+	other, _ := selectSample(models.SamplingSpec{Size: 20, Seed: 7}, report)
+	if reflect.DeepEqual(first, other) {
+		t.Error("different seeds produced the same sample")
+	}
+}
+
+func TestSelectSampleStratified(t *testing.T) {
+	report := sampleReport(30, 60, 10)
+	selected, info := selectSample(models.SamplingSpec{Percentage: 10, StratifyBy: StratifyByRelevance, Seed: 1}, report)
+
+	want := []models.SamplingStratum{
+		{Name: stratumIrrelevant, Population: 60, Sampled: 6},
+		{Name: stratumMissing, Population: 10, Sampled: 1},
+		{Name: stratumRelevant, Population: 30, Sampled: 3},
+	}
+	if !reflect.DeepEqual(info.Strata, want) {
+		t.Errorf("Strata = %+v, want %+v", info.Strata, want)
+	}
+
+	relevant := 0
+	for id := range selected {
+		if item, ok := report.Items[id]; ok && item.IsRelevant {
+			relevant++
+		}
+	}
+	if relevant != 3 {
+		t.Errorf("selected %d relevant items, want 3", relevant)
+	}
+}
+
+func TestAllocateProportionally(t *testing.T) {
+	strata := map[string][]string{
+		"a": make([]string, 1),
+		"b": make([]string, 1),
+		"c": make([]string, 1),
+	}
+	quotas := allocateProportionally(2, []string{"a", "b", "c"}, strata)
+	if quotas["a"]+quotas["b"]+quotas["c"] != 2 {
+		t.Errorf("quotas = %v, want a total of 2", quotas)
+	}
+}
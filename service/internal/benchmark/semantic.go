@@ -0,0 +1,139 @@
+package benchmark
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/openai"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+// maxEmbeddingInputChars keeps raw inputs comfortably inside embedding model context limits
+const maxEmbeddingInputChars = 16000
+
+// cosineSimilarity returns the cosine similarity of two vectors, or 0 if either is empty or zero
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// truncateForEmbedding trims text to the embedding input limit
+func truncateForEmbedding(text string) string {
+	if len(text) <= maxEmbeddingInputChars {
+		return text
+	}
+	// Back off to a rune boundary
+	cut := maxEmbeddingInputChars
+	for cut > 0 && text[cut]&0xC0 == 0x80 {
+		cut--
+	}
+	return text[:cut]
+}
+
+// newEmbedder returns an embeddings client, or nil if no embedding model is configured
+func (bs *BenchmarkService) newEmbedder() openai.OpenAIClient {
+	if bs.embeddingModel == "" {
+		return nil
+	}
+	return openai.New(bs.embeddingURL, bs.embeddingAPIKey, bs.embeddingModel)
+}
+
+// previousRunItems finds the most recent earlier run for the same persona and returns its items by ID
+func previousRunItems(runData *models.PersistedRunData) (string, map[string]anpmodels.Item) {
+	runs, err := storage.ListRunMetadata(-1)
+	if err != nil {
+		log.Printf("Error listing runs to find previous run: %v", err)
+		return "", nil
+	}
+
+	var candidates []models.RunMetadata
+	for _, run := range runs {
+		if run.ID != runData.RunID && run.PersonaName == runData.Persona.Name && run.RunDate.Before(runData.RunDate) {
+			candidates = append(candidates, run)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].RunDate.After(candidates[j].RunDate)
+	})
+
+	previous, err := storage.GetRunData(candidates[0].ID)
+	if err != nil {
+		log.Printf("Error getting previous run %s: %v", candidates[0].ID, err)
+		return "", nil
+	}
+	return previous.RunID, itemsByID(previous.EntrySummaries)
+}
+
+// semanticSimilarity embeds a summary, its source and, if available, the previous run's summary of the same item
+func (bs *BenchmarkService) semanticSimilarity(embedder openai.OpenAIClient, source, summary string, previous *anpmodels.Item, previousRunID string) (*models.SemanticSimilarity, models.TokenUsage, error) {
+	inputs := []string{truncateForEmbedding(source), truncateForEmbedding(summary)}
+	if previous != nil {
+		inputs = append(inputs, truncateForEmbedding(previous.Summary))
+	}
+
+	start := time.Now()
+	embeddings, embeddingUsage, err := embedder.Embeddings(inputs)
+	usage := bs.tokenUsage(embedder.GetModelName(), embeddingUsage, time.Since(start))
+	if err != nil {
+		return nil, usage, fmt.Errorf("failed to embed summary: %w", err)
+	}
+
+	similarity := &models.SemanticSimilarity{
+		SourceSimilarity: cosineSimilarity(embeddings[1], embeddings[0]),
+	}
+	if previous != nil {
+		similarity.CrossRunSimilarity = cosineSimilarity(embeddings[1], embeddings[2])
+		similarity.PreviousRunID = previousRunID
+	}
+	return similarity, usage, nil
+}
+
+// summariseSemantic aggregates the per-item similarities, or returns nil if none were computed
+func summariseSemantic(evaluations map[string]models.EvaluationResult, previousRunID, embeddingModel string) *models.SemanticSummary {
+	summary := &models.SemanticSummary{
+		PreviousRunID:  previousRunID,
+		EmbeddingModel: embeddingModel,
+	}
+
+	var sourceTotal, crossRunTotal float64
+	for _, eval := range evaluations {
+		if eval.Semantic == nil {
+			continue
+		}
+		summary.ItemsCompared++
+		sourceTotal += eval.Semantic.SourceSimilarity
+		if eval.Semantic.PreviousRunID != "" {
+			summary.CrossRunItems++
+			crossRunTotal += eval.Semantic.CrossRunSimilarity
+		}
+	}
+	if summary.ItemsCompared == 0 {
+		return nil
+	}
+
+	summary.MeanSourceSimilarity = sourceTotal / float64(summary.ItemsCompared)
+	if summary.CrossRunItems > 0 {
+		summary.MeanCrossRunSimilarity = crossRunTotal / float64(summary.CrossRunItems)
+	}
+	return summary
+}
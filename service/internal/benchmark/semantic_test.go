@@ -0,0 +1,164 @@
+package benchmark
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+// initTestDB opens a fresh database for the duration of a test
+func initTestDB(t *testing.T) {
+	t.Helper()
+	if err := storage.InitDB(t.TempDir(), 0); err != nil {
+		t.Fatalf("InitDB() error = %v", err)
+	}
+	t.Cleanup(storage.CloseDB)
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []float64
+		expected float64
+	}{
+		{name: "identical", a: []float64{1, 2, 3}, b: []float64{1, 2, 3}, expected: 1},
+		{name: "scaled", a: []float64{1, 2}, b: []float64{2, 4}, expected: 1},
+		{name: "orthogonal", a: []float64{1, 0}, b: []float64{0, 1}, expected: 0},
+		{name: "opposite", a: []float64{1, 1}, b: []float64{-1, -1}, expected: -1},
+		{name: "empty", a: nil, b: nil, expected: 0},
+		{name: "zero vector", a: []float64{0, 0}, b: []float64{1, 1}, expected: 0},
+		{name: "mismatched lengths", a: []float64{1, 0}, b: []float64{1, 0, 0}, expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cosineSimilarity(tt.a, tt.b); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruncateForEmbedding(t *testing.T) {
+	short := "short text"
+	if got := truncateForEmbedding(short); got != short {
+		t.Errorf("expected short text unchanged, got %q", got)
+	}
+
+	ascii := strings.Repeat("a", maxEmbeddingInputChars+10)
+	if got := truncateForEmbedding(ascii); len(got) != maxEmbeddingInputChars {
+		t.Errorf("expected %d bytes, got %d", maxEmbeddingInputChars, len(got))
+	}
+
+	// A three-byte rune straddles the limit, so the cut backs off to before it
+	multibyte := strings.Repeat("a", maxEmbeddingInputChars-1) + "€" + "tail"
+	got := truncateForEmbedding(multibyte)
+	if !utf8.ValidString(got) {
+		t.Error("truncation split a rune")
+	}
+	if len(got) != maxEmbeddingInputChars-1 {
+		t.Errorf("expected %d bytes, got %d", maxEmbeddingInputChars-1, len(got))
+	}
+}
+
+func TestSemanticSimilarity(t *testing.T) {
+	embedder := &fakeJudgeClient{embed: func(inputs []string) ([][]float64, error) {
+		vectors := map[string][]float64{
+			"source":   {1, 0},
+			"summary":  {1, 1},
+			"previous": {0, 1},
+		}
+		embeddings := make([][]float64, len(inputs))
+		for i, input := range inputs {
+			embeddings[i] = vectors[input]
+		}
+		return embeddings, nil
+	}}
+	bs := &BenchmarkService{}
+
+	similarity, usage, err := bs.semanticSimilarity(embedder, "source", "summary", &anpmodels.Item{Summary: "previous"}, "prev-run")
+	if err != nil {
+		t.Fatalf("semanticSimilarity() error = %v", err)
+	}
+	if math.Abs(similarity.SourceSimilarity-math.Sqrt2/2) > 1e-9 || math.Abs(similarity.CrossRunSimilarity-math.Sqrt2/2) > 1e-9 {
+		t.Errorf("similarity = %+v, want 0.707 to source and previous summary", similarity)
+	}
+	if similarity.PreviousRunID != "prev-run" || usage.PromptTokens != 3 {
+		t.Errorf("similarity = %+v, usage = %+v", similarity, usage)
+	}
+
+	similarity, _, err = bs.semanticSimilarity(embedder, "source", "summary", nil, "")
+	if err != nil || similarity.PreviousRunID != "" || similarity.CrossRunSimilarity != 0 {
+		t.Errorf("without a previous item, similarity = %+v, error = %v", similarity, err)
+	}
+
+	failing := &fakeJudgeClient{embed: func([]string) ([][]float64, error) { return nil, errors.New("unavailable") }}
+	if _, _, err := bs.semanticSimilarity(failing, "source", "summary", nil, ""); err == nil {
+		t.Error("expected an error when embedding fails")
+	}
+}
+
+func TestSummariseSemantic(t *testing.T) {
+	if summary := summariseSemantic(map[string]models.EvaluationResult{"a": {}}, "prev", "embed"); summary != nil {
+		t.Errorf("expected no summary without similarities, got %+v", summary)
+	}
+
+	evaluations := map[string]models.EvaluationResult{
+		"a": {Semantic: &models.SemanticSimilarity{SourceSimilarity: 0.9, CrossRunSimilarity: 0.8, PreviousRunID: "prev"}},
+		"b": {Semantic: &models.SemanticSimilarity{SourceSimilarity: 0.7}},
+		"c": {},
+	}
+	summary := summariseSemantic(evaluations, "prev", "embed")
+	if summary == nil {
+		t.Fatal("expected a summary")
+	}
+	if summary.ItemsCompared != 2 || summary.CrossRunItems != 1 {
+		t.Errorf("summary = %+v, want 2 items compared and 1 across runs", summary)
+	}
+	if math.Abs(summary.MeanSourceSimilarity-0.8) > 1e-9 || math.Abs(summary.MeanCrossRunSimilarity-0.8) > 1e-9 {
+		t.Errorf("summary = %+v, want means of 0.8", summary)
+	}
+	if summary.PreviousRunID != "prev" || summary.EmbeddingModel != "embed" {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestPreviousRunItems(t *testing.T) {
+	initTestDB(t)
+
+	now := time.Now()
+	save := func(runID, persona string, runDate time.Time, itemIDs ...string) models.PersistedRunData {
+		t.Helper()
+		run := models.PersistedRunData{RunID: runID}
+		run.Persona.Name = persona
+		run.RunDate = runDate
+		for _, id := range itemIDs {
+			run.EntrySummaries = append(run.EntrySummaries, anpmodels.EntrySummary{Results: anpmodels.Item{ID: id}})
+		}
+		if err := storage.SaveRunData(runID, run); err != nil {
+			t.Fatalf("SaveRunData(%s) error = %v", runID, err)
+		}
+		return run
+	}
+
+	first := save("first", "alpha", now.Add(-3*time.Hour), "old")
+	save("previous", "alpha", now.Add(-2*time.Hour), "a", "b")
+	save("other-persona", "beta", now.Add(-time.Hour), "x")
+	current := save("current", "alpha", now, "a")
+	save("later", "alpha", now.Add(time.Hour), "y")
+
+	runID, items := previousRunItems(&current)
+	if runID != "previous" || len(items) != 2 || items["a"].ID != "a" {
+		t.Errorf("previousRunItems() = %s, %v, want the previous alpha run", runID, items)
+	}
+
+	if runID, items := previousRunItems(&first); runID != "" || items != nil {
+		t.Errorf("previousRunItems() of the first run = %s, %v, want none", runID, items)
+	}
+}
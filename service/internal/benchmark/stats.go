@@ -0,0 +1,138 @@
+package benchmark
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+const (
+	bootstrapIterations = 2000
+	confidenceLevel     = 0.95
+	// bootstrapSeed makes intervals reproducible, so recomputing aggregates does not move them
+	bootstrapSeed = 1
+
+	permutationIterations = 10000
+	significanceLevel     = 0.05
+)
+
+// mean returns the arithmetic mean, or 0 for no values
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// bootstrapCI estimates a percentile bootstrap confidence interval for the mean of values
+func bootstrapCI(values []float64, iterations int, level float64, rng *rand.Rand) *models.ConfidenceInterval {
+	if len(values) == 0 {
+		return nil
+	}
+
+	means := make([]float64, iterations)
+	resample := make([]float64, len(values))
+	for i := range means {
+		for j := range resample {
+			resample[j] = values[rng.IntN(len(values))]
+		}
+		means[i] = mean(resample)
+	}
+	sort.Float64s(means)
+
+	tail := (1 - level) / 2
+	lower := means[int(tail*float64(iterations))]
+	upper := means[min(int((1-tail)*float64(iterations)), iterations-1)]
+	return &models.ConfidenceInterval{Lower: lower, Upper: upper, Level: level}
+}
+
+// scoreSamples returns the per-item quality scores and relevance outcomes (1 or 0) that the aggregates average
+func scoreSamples(evaluations map[string]models.EvaluationResult) (quality, relevance []float64) {
+	// Sort by ID so the bootstrap sees the same order every time
+	ids := make([]string, 0, len(evaluations))
+	for id, eval := range evaluations {
+		if isScored(eval) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		eval := evaluations[id]
+		quality = append(quality, ratingScore(eval.QualityRating))
+		if eval.RelevanceCorrect {
+			relevance = append(relevance, 1)
+		} else {
+			relevance = append(relevance, 0)
+		}
+	}
+	return quality, relevance
+}
+
+// scoreIntervals computes bootstrap intervals for the quality score and relevance accuracy
+func scoreIntervals(evaluations map[string]models.EvaluationResult) (quality, relevance *models.ConfidenceInterval) {
+	qualityScores, relevanceOutcomes := scoreSamples(evaluations)
+	rng := rand.New(rand.NewPCG(bootstrapSeed, 0))
+	return bootstrapCI(qualityScores, bootstrapIterations, confidenceLevel, rng),
+		bootstrapCI(relevanceOutcomes, bootstrapIterations, confidenceLevel, rng)
+}
+
+// permutationTest returns the two-sided p-value for the difference in means between base and head,
+// by shuffling the pooled values between the two groups
+func permutationTest(base, head []float64, iterations int, rng *rand.Rand) float64 {
+	if len(base) == 0 || len(head) == 0 {
+		return 1
+	}
+
+	observed := math.Abs(mean(head) - mean(base))
+	pooled := append(append(make([]float64, 0, len(base)+len(head)), base...), head...)
+
+	// Tolerate floating point noise when a shuffle reproduces the observed split
+	const epsilon = 1e-9
+	extreme := 0
+	for i := 0; i < iterations; i++ {
+		rng.Shuffle(len(pooled), func(a, b int) { pooled[a], pooled[b] = pooled[b], pooled[a] })
+		diff := math.Abs(mean(pooled[len(base):]) - mean(pooled[:len(base)]))
+		if diff >= observed-epsilon {
+			extreme++
+		}
+	}
+	// Counting the observed split itself keeps the p-value above zero
+	return float64(extreme+1) / float64(iterations+1)
+}
+
+// significanceTest compares the mean of head against base with a permutation test
+func significanceTest(base, head []float64, rng *rand.Rand) *models.SignificanceTest {
+	if len(base) == 0 || len(head) == 0 {
+		return nil
+	}
+	pValue := permutationTest(base, head, permutationIterations, rng)
+	return &models.SignificanceTest{
+		BaseMean:     mean(base),
+		HeadMean:     mean(head),
+		Difference:   mean(head) - mean(base),
+		PValue:       pValue,
+		Significant:  pValue < significanceLevel,
+		Permutations: permutationIterations,
+	}
+}
+
+// compareSignificance tests whether the quality score and relevance accuracy of two benchmarks differ
+func compareSignificance(base, head *models.BenchmarkResults) *models.BenchmarkSignificance {
+	baseQuality, baseRelevance := scoreSamples(base.DetailedEvaluations)
+	headQuality, headRelevance := scoreSamples(head.DetailedEvaluations)
+	rng := rand.New(rand.NewPCG(bootstrapSeed, 0))
+	return &models.BenchmarkSignificance{
+		BaseBenchmarkID:   base.BenchmarkID,
+		HeadBenchmarkID:   head.BenchmarkID,
+		Alpha:             significanceLevel,
+		QualityScore:      significanceTest(baseQuality, headQuality, rng),
+		RelevanceAccuracy: significanceTest(baseRelevance, headRelevance, rng),
+	}
+}
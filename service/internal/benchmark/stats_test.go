@@ -0,0 +1,54 @@
+package benchmark
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestBootstrapCI(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 0))
+
+	if ci := bootstrapCI(nil, 100, 0.95, rng); ci != nil {
+		t.Errorf("bootstrapCI(nil) = %+v, want nil", ci)
+	}
+
+	constant := []float64{75, 75, 75, 75}
+	ci := bootstrapCI(constant, 500, 0.95, rng)
+	if ci.Lower != 75 || ci.Upper != 75 {
+		t.Errorf("constant values gave interval %+v, want [75, 75]", ci)
+	}
+
+	values := make([]float64, 0, 200)
+	for i := 0; i < 200; i++ {
+		values = append(values, float64(i%2)*100)
+	}
+	ci = bootstrapCI(values, 2000, 0.95, rng)
+	if ci.Lower >= 50 || ci.Upper <= 50 {
+		t.Errorf("interval %+v does not contain the mean of 50", ci)
+	}
+	if ci.Upper-ci.Lower > 20 {
+		t.Errorf("interval %+v is wider than expected for 200 values", ci)
+	}
+}
+
+func TestPermutationTest(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 0))
+
+	same := []float64{0, 50, 100, 0, 50, 100, 0, 50, 100, 0}
+	if p := permutationTest(same, same, 2000, rng); p < 0.5 {
+		t.Errorf("identical groups gave p = %.3f, want a large p-value", p)
+	}
+
+	low := make([]float64, 30)
+	high := make([]float64, 30)
+	for i := range high {
+		high[i] = 100
+	}
+	if p := permutationTest(low, high, 2000, rng); p >= 0.01 {
+		t.Errorf("separated groups gave p = %.3f, want p < 0.01", p)
+	}
+
+	if p := permutationTest(nil, high, 2000, rng); p != 1 {
+		t.Errorf("empty group gave p = %.3f, want 1", p)
+	}
+}
@@ -0,0 +1,115 @@
+package benchmark
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/openai"
+)
+
+const (
+	phaseJudging   = "judging"
+	phaseEmbedding = "embedding"
+)
+
+// tokenUsage records a single request's usage, priced from the configured price table
+func (bs *BenchmarkService) tokenUsage(model string, usage openai.Usage, latency time.Duration) models.TokenUsage {
+	return models.TokenUsage{
+		Requests:         1,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+		LatencyMs:        latency.Milliseconds(),
+		EstimatedCost:    estimateCost(bs.prices, model, usage.PromptTokens, usage.CompletionTokens),
+	}
+}
+
+// estimateCost prices tokens in USD, returning 0 for models without a price
+func estimateCost(prices map[string]internal.ModelPrice, model string, promptTokens, completionTokens int64) float64 {
+	price, ok := prices[model]
+	if !ok {
+		return 0
+	}
+	return (float64(promptTokens)*price.Input + float64(completionTokens)*price.Output) / 1e6
+}
+
+// addUsage adds u to total
+func addUsage(total *models.TokenUsage, u models.TokenUsage) {
+	total.Requests += u.Requests
+	total.PromptTokens += u.PromptTokens
+	total.CompletionTokens += u.CompletionTokens
+	total.TotalTokens += u.TotalTokens
+	total.LatencyMs += u.LatencyMs
+	total.EstimatedCost += u.EstimatedCost
+}
+
+// summariseUsage totals the usage recorded on every evaluation, including verdicts replaced by
+// re-evaluation since their tokens were still paid for. The wall-clock duration is kept from
+// any previous summary.
+func summariseUsage(results *models.BenchmarkResults, prices map[string]internal.ModelPrice) *models.BenchmarkUsage {
+	summary := &models.BenchmarkUsage{
+		Phases: make(map[string]models.TokenUsage),
+		Models: make(map[string]models.TokenUsage),
+	}
+	if results.Usage != nil {
+		summary.DurationMs = results.Usage.DurationMs
+	}
+
+	// With a single judge, verdicts are not kept per judge, so judging usage belongs to its model
+	singleJudgeModel := ""
+	if results.Judging != nil && len(results.Judging.Judges) == 1 {
+		singleJudgeModel = results.Judging.Judges[0].Model
+	}
+	embeddingModel := ""
+	if results.SemanticSummary != nil {
+		embeddingModel = results.SemanticSummary.EmbeddingModel
+	}
+
+	add := func(phase, model string, u models.TokenUsage) {
+		if u.Requests == 0 {
+			return
+		}
+		addUsage(&summary.Total, u)
+		phaseTotal := summary.Phases[phase]
+		addUsage(&phaseTotal, u)
+		summary.Phases[phase] = phaseTotal
+		if model != "" {
+			modelTotal := summary.Models[model]
+			addUsage(&modelTotal, u)
+			summary.Models[model] = modelTotal
+		}
+	}
+
+	addEvaluation := func(eval models.EvaluationResult) {
+		if eval.Usage == nil {
+			return
+		}
+		if len(eval.JudgeVerdicts) > 0 {
+			for _, v := range eval.JudgeVerdicts {
+				if v.Usage != nil {
+					add(phaseJudging, v.Model, *v.Usage)
+				}
+			}
+		} else {
+			add(phaseJudging, singleJudgeModel, eval.Usage.Judging)
+		}
+		add(phaseEmbedding, embeddingModel, eval.Usage.Embedding)
+	}
+
+	for _, eval := range results.DetailedEvaluations {
+		addEvaluation(eval)
+		for _, previous := range eval.PreviousVerdicts {
+			addEvaluation(previous)
+		}
+	}
+
+	for model, u := range summary.Models {
+		if _, ok := prices[model]; !ok && u.TotalTokens > 0 {
+			summary.UnpricedModels = append(summary.UnpricedModels, model)
+		}
+	}
+	sort.Strings(summary.UnpricedModels)
+	return summary
+}
@@ -0,0 +1,62 @@
+package benchmark
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestEstimateCost(t *testing.T) {
+	prices := map[string]internal.ModelPrice{"gpt-4o": {Input: 2.5, Output: 10}}
+
+	if got := estimateCost(prices, "gpt-4o", 1000, 500); math.Abs(got-0.0075) > 1e-12 {
+		t.Errorf("estimateCost() = %v, want 0.0075", got)
+	}
+	if got := estimateCost(prices, "unknown", 1000, 500); got != 0 {
+		t.Errorf("estimateCost() for unpriced model = %v, want 0", got)
+	}
+}
+
+func TestSummariseUsage(t *testing.T) {
+	judging := models.TokenUsage{Requests: 1, PromptTokens: 100, CompletionTokens: 20, TotalTokens: 120, EstimatedCost: 0.5}
+	embedding := models.TokenUsage{Requests: 1, PromptTokens: 50, TotalTokens: 50}
+
+	results := &models.BenchmarkResults{
+		Judging:         &models.JudgeSettings{Judges: []models.JudgeInfo{{Name: "judge", Model: "gpt-4o"}}},
+		SemanticSummary: &models.SemanticSummary{EmbeddingModel: "embed"},
+		Usage:           &models.BenchmarkUsage{DurationMs: 1234},
+		DetailedEvaluations: map[string]models.EvaluationResult{
+			"a": {
+				Usage: &models.ItemUsage{Judging: judging, Embedding: embedding},
+				PreviousVerdicts: []models.EvaluationResult{
+					{Usage: &models.ItemUsage{Judging: judging}},
+				},
+			},
+			"b": {Status: models.ItemStatusMissingFromOutput},
+		},
+	}
+
+	summary := summariseUsage(results, map[string]internal.ModelPrice{"gpt-4o": {Input: 1}})
+
+	if summary.Total.Requests != 3 || summary.Total.TotalTokens != 290 {
+		t.Errorf("Total = %+v, want 3 requests and 290 tokens", summary.Total)
+	}
+	if summary.Total.EstimatedCost != 1.0 {
+		t.Errorf("Total.EstimatedCost = %v, want 1.0", summary.Total.EstimatedCost)
+	}
+	if summary.Phases[phaseJudging].TotalTokens != 240 || summary.Phases[phaseEmbedding].TotalTokens != 50 {
+		t.Errorf("Phases = %+v", summary.Phases)
+	}
+	if summary.Models["gpt-4o"].Requests != 2 || summary.Models["embed"].Requests != 1 {
+		t.Errorf("Models = %+v", summary.Models)
+	}
+	if !reflect.DeepEqual(summary.UnpricedModels, []string{"embed"}) {
+		t.Errorf("UnpricedModels = %v, want [embed]", summary.UnpricedModels)
+	}
+	if summary.DurationMs != 1234 {
+		t.Errorf("DurationMs = %d, want 1234", summary.DurationMs)
+	}
+}
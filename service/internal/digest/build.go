@@ -0,0 +1,143 @@
+package digest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// worstItemsPerPersona caps the items highlighted for each persona
+const worstItemsPerPersona = 3
+
+// ratingRank orders the ratings highlighted as worst items, worst first
+var ratingRank = map[string]int{"Poor": 0, "Fair": 1}
+
+// build summarises the benchmarks in [from, to) per persona. Benchmarks from the preceding period
+// of the same length are used for the change in average quality.
+func build(benchmarks []models.BenchmarkResults, alerts []models.Alert, from, to time.Time) models.Digest {
+	previousFrom := from.Add(-to.Sub(from))
+
+	current := make(map[string][]models.BenchmarkResults)
+	previous := make(map[string][]models.BenchmarkResults)
+	for _, results := range benchmarks {
+		if results.FailureReason != "" || results.PersonaName == "" {
+			continue
+		}
+		switch {
+		case !results.Timestamp.Before(from) && results.Timestamp.Before(to):
+			current[results.PersonaName] = append(current[results.PersonaName], results)
+		case !results.Timestamp.Before(previousFrom) && results.Timestamp.Before(from):
+			previous[results.PersonaName] = append(previous[results.PersonaName], results)
+		}
+	}
+
+	digest := models.Digest{
+		From:       from,
+		To:         to,
+		Personas:   []models.PersonaDigest{},
+		OpenAlerts: []models.Alert{},
+	}
+	for name, personaBenchmarks := range current {
+		digest.Personas = append(digest.Personas, personaDigest(name, personaBenchmarks, previous[name]))
+	}
+	sort.Slice(digest.Personas, func(i, j int) bool {
+		return digest.Personas[i].PersonaName < digest.Personas[j].PersonaName
+	})
+
+	for _, alert := range alerts {
+		if alert.Status != models.AlertStatusResolved {
+			digest.OpenAlerts = append(digest.OpenAlerts, alert)
+		}
+	}
+	// Critical alerts first, then newest first
+	sort.Slice(digest.OpenAlerts, func(i, j int) bool {
+		a, b := digest.OpenAlerts[i], digest.OpenAlerts[j]
+		if a.Severity != b.Severity {
+			return a.Severity == models.AlertSeverityCritical
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	return digest
+}
+
+// personaDigest summarises one persona's benchmarks for the period
+func personaDigest(name string, benchmarks, previous []models.BenchmarkResults) models.PersonaDigest {
+	sort.Slice(benchmarks, func(i, j int) bool {
+		return benchmarks[i].Timestamp.Before(benchmarks[j].Timestamp)
+	})
+
+	summary := models.PersonaDigest{
+		PersonaName: name,
+		Benchmarks:  len(benchmarks),
+	}
+	var qualityTotal, relevanceTotal float64
+	for _, results := range benchmarks {
+		summary.QualityScores = append(summary.QualityScores, results.QualityScore)
+		qualityTotal += results.QualityScore
+		relevanceTotal += results.RelevanceAccuracy
+	}
+	summary.AverageQualityScore = qualityTotal / float64(len(benchmarks))
+	summary.AverageRelevanceAccuracy = relevanceTotal / float64(len(benchmarks))
+	summary.LatestQualityScore = benchmarks[len(benchmarks)-1].QualityScore
+
+	if len(previous) > 0 {
+		var previousTotal float64
+		for _, results := range previous {
+			previousTotal += results.QualityScore
+		}
+		change := summary.AverageQualityScore - previousTotal/float64(len(previous))
+		summary.QualityChange = &change
+	}
+
+	summary.WorstItems = worstItems(benchmarks)
+	return summary
+}
+
+// worstItems returns the lowest rated evaluated items, worst and most recent first
+func worstItems(benchmarks []models.BenchmarkResults) []models.DigestItem {
+	type candidate struct {
+		item      models.DigestItem
+		rank      int
+		timestamp time.Time
+	}
+
+	var candidates []candidate
+	for _, results := range benchmarks {
+		for id, eval := range results.DetailedEvaluations {
+			rank, low := ratingRank[eval.QualityRating]
+			if !low || (eval.Status != "" && eval.Status != models.ItemStatusEvaluated) {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				item: models.DigestItem{
+					BenchmarkID:   results.BenchmarkID,
+					RunID:         results.RunID,
+					ItemID:        id,
+					QualityRating: eval.QualityRating,
+					Explanation:   eval.QualityExplanation,
+				},
+				rank:      rank,
+				timestamp: results.Timestamp,
+			})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if !a.timestamp.Equal(b.timestamp) {
+			return a.timestamp.After(b.timestamp)
+		}
+		return a.item.ItemID < b.item.ItemID
+	})
+
+	var items []models.DigestItem
+	for i := 0; i < len(candidates) && i < worstItemsPerPersona; i++ {
+		items = append(items, candidates[i].item)
+	}
+	return items
+}
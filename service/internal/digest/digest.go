@@ -0,0 +1,171 @@
+package digest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/scheduler"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// Service builds digests of audit results and emails them on a schedule
+type Service struct {
+	spec *internal.Specification
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewService creates a digest service using the SMTP and digest settings from the specification
+func NewService(spec *internal.Specification) *Service {
+	return &Service{spec: spec}
+}
+
+// Preview renders the digest for [from, to) without sending it
+func (s *Service) Preview(from, to time.Time) (*models.RenderedDigest, error) {
+	benchmarks, err := storage.ListBenchmarkResults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list benchmark results: %w", err)
+	}
+	alerts, err := storage.ListAlerts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+	return render(build(benchmarks, alerts, from, to), s.spec.DashboardURL)
+}
+
+// DefaultPeriod returns the period a digest sent at now covers
+func (s *Service) DefaultPeriod(now time.Time) (time.Time, time.Time) {
+	return now.Add(-s.spec.DigestPeriod), now
+}
+
+// Send renders the digest for the period ending now and emails it to the configured recipients
+func (s *Service) Send(now time.Time) error {
+	if len(s.spec.DigestRecipients) == 0 {
+		return fmt.Errorf("no digest recipients are configured")
+	}
+	if s.spec.SMTPHost == "" || s.spec.SMTPFrom == "" {
+		return fmt.Errorf("SMTP is not configured")
+	}
+
+	rendered, err := s.Preview(s.DefaultPeriod(now))
+	if err != nil {
+		return err
+	}
+
+	message, err := buildMessage(s.spec.SMTPFrom, s.spec.DigestRecipients, rendered, now)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.spec.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", s.spec.SMTPUsername, s.spec.SMTPPassword, s.spec.SMTPHost)
+	}
+	addr := net.JoinHostPort(s.spec.SMTPHost, strconv.Itoa(s.spec.SMTPPort))
+	if err := smtp.SendMail(addr, auth, s.spec.SMTPFrom, s.spec.DigestRecipients, message); err != nil {
+		return fmt.Errorf("failed to send digest via %s: %w", addr, err)
+	}
+
+	log.Printf("Sent digest %q to %d recipient(s)", rendered.Subject, len(s.spec.DigestRecipients))
+	return nil
+}
+
+// Start sends digests on the configured schedule until Stop is called.
+// Nothing is started when there are no recipients or no schedule.
+func (s *Service) Start() error {
+	if len(s.spec.DigestRecipients) == 0 || s.spec.DigestSchedule == "" {
+		return nil
+	}
+	cron, err := scheduler.ParseCron(s.spec.DigestSchedule)
+	if err != nil {
+		return fmt.Errorf("invalid digest schedule: %w", err)
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		for {
+			next := cron.Next(time.Now())
+			if next.IsZero() {
+				log.Printf("Digest schedule %q never runs, digests will not be sent", s.spec.DigestSchedule)
+				return
+			}
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-s.stop:
+				timer.Stop()
+				return
+			case now := <-timer.C:
+				if err := s.Send(now); err != nil {
+					log.Printf("Error sending digest: %v", err)
+				}
+			}
+		}
+	}()
+	log.Printf("Digest emails scheduled with %q", s.spec.DigestSchedule)
+	return nil
+}
+
+// Stop stops sending scheduled digests
+func (s *Service) Stop() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+}
+
+// buildMessage assembles a multipart/alternative email with text and HTML parts
+func buildMessage(from string, to []string, rendered *models.RenderedDigest, now time.Time) ([]byte, error) {
+	boundaryBytes := make([]byte, 12)
+	if _, err := rand.Read(boundaryBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate MIME boundary: %w", err)
+	}
+	boundary := "digest-" + hex.EncodeToString(boundaryBytes)
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mimeHeader(rendered.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", rendered.Text},
+		{"text/html", rendered.HTML},
+	} {
+		fmt.Fprintf(&msg, "--%s\r\n", boundary)
+		fmt.Fprintf(&msg, "Content-Type: %s; charset=UTF-8\r\n", part.contentType)
+		msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		qp := quotedprintable.NewWriter(&msg)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("failed to encode %s part: %w", part.contentType, err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode %s part: %w", part.contentType, err)
+		}
+		msg.WriteString("\r\n")
+	}
+	fmt.Fprintf(&msg, "--%s--\r\n", boundary)
+	return msg.Bytes(), nil
+}
+
+// mimeHeader encodes a header value that may contain non-ASCII characters
+func mimeHeader(value string) string {
+	return mime.QEncoding.Encode("utf-8", value)
+}
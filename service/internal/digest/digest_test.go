@@ -0,0 +1,113 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestBuild(t *testing.T) {
+	to := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -7)
+
+	benchmarks := []models.BenchmarkResults{
+		// Previous period
+		{BenchmarkID: "p1", PersonaName: "alpha", QualityScore: 60, Timestamp: from.AddDate(0, 0, -3)},
+		// Current period, out of order
+		{BenchmarkID: "c2", PersonaName: "alpha", QualityScore: 80, RelevanceAccuracy: 0.875, Timestamp: from.AddDate(0, 0, 5),
+			DetailedEvaluations: map[string]models.EvaluationResult{
+				"good": {QualityRating: "Good", Status: models.ItemStatusEvaluated},
+				"fair": {QualityRating: "Fair", Status: models.ItemStatusEvaluated},
+				"err":  {QualityRating: "Poor", Status: models.ItemStatusJudgeError},
+			}},
+		{BenchmarkID: "c1", PersonaName: "alpha", QualityScore: 70, RelevanceAccuracy: 0.625, Timestamp: from.AddDate(0, 0, 1),
+			DetailedEvaluations: map[string]models.EvaluationResult{
+				"poor": {QualityRating: "Poor", Status: models.ItemStatusEvaluated},
+			}},
+		{BenchmarkID: "b1", PersonaName: "beta", QualityScore: 50, Timestamp: from.AddDate(0, 0, 2)},
+		// Excluded: failed, and after the period
+		{BenchmarkID: "f", PersonaName: "alpha", FailureReason: "boom", Timestamp: from.AddDate(0, 0, 2)},
+		{BenchmarkID: "late", PersonaName: "alpha", QualityScore: 0, Timestamp: to},
+	}
+	alerts := []models.Alert{
+		{ID: "resolved", Status: models.AlertStatusResolved},
+		{ID: "warn", Status: models.AlertStatusOpen, Severity: models.AlertSeverityWarning, CreatedAt: to},
+		{ID: "crit", Status: models.AlertStatusAcknowledged, Severity: models.AlertSeverityCritical, CreatedAt: from},
+	}
+
+	digest := build(benchmarks, alerts, from, to)
+
+	if len(digest.Personas) != 2 || digest.Personas[0].PersonaName != "alpha" {
+		t.Fatalf("Personas = %+v, want alpha and beta", digest.Personas)
+	}
+	alpha := digest.Personas[0]
+	if alpha.Benchmarks != 2 || alpha.AverageQualityScore != 75 || alpha.LatestQualityScore != 80 || alpha.AverageRelevanceAccuracy != 0.75 {
+		t.Errorf("alpha = %+v", alpha)
+	}
+	if len(alpha.QualityScores) != 2 || alpha.QualityScores[0] != 70 {
+		t.Errorf("QualityScores = %v, want oldest first", alpha.QualityScores)
+	}
+	if alpha.QualityChange == nil || *alpha.QualityChange != 15 {
+		t.Errorf("QualityChange = %v, want +15", alpha.QualityChange)
+	}
+	if len(alpha.WorstItems) != 2 || alpha.WorstItems[0].ItemID != "poor" || alpha.WorstItems[1].ItemID != "fair" {
+		t.Errorf("WorstItems = %+v, want poor then fair", alpha.WorstItems)
+	}
+	if digest.Personas[1].QualityChange != nil {
+		t.Error("beta has no previous period, want no QualityChange")
+	}
+
+	if len(digest.OpenAlerts) != 2 || digest.OpenAlerts[0].ID != "crit" {
+		t.Errorf("OpenAlerts = %+v, want crit then warn", digest.OpenAlerts)
+	}
+}
+
+func TestRender(t *testing.T) {
+	change := -4.5
+	digest := models.Digest{
+		From: time.Date(2025, time.January, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC),
+		Personas: []models.PersonaDigest{{
+			PersonaName:              "alpha <test>",
+			Benchmarks:               1,
+			QualityScores:            []float64{70},
+			AverageRelevanceAccuracy: 0.9,
+			QualityChange:            &change,
+			WorstItems:               []models.DigestItem{{RunID: "run-1", ItemID: "item-1", QualityRating: "Poor"}},
+		}},
+		OpenAlerts: []models.Alert{{Severity: models.AlertSeverityCritical, PersonaName: "alpha", Message: "Score dropped"}},
+	}
+
+	rendered, err := render(digest, "http://dashboard.test/")
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if rendered.Subject != "Audit digest: 8 Jan 2025 – 15 Jan 2025" {
+		t.Errorf("Subject = %q", rendered.Subject)
+	}
+	for _, want := range []string{"alpha &lt;test&gt;", "-4.5", "90.0%", `href="http://dashboard.test/run/run-1"`, "Score dropped"} {
+		if !strings.Contains(rendered.HTML, want) {
+			t.Errorf("HTML does not contain %q", want)
+		}
+	}
+	for _, want := range []string{"== alpha <test> ==", "-4.5", "Relevance accuracy: 90.0%", "Poor: item-1 (run run-1)", "[critical] alpha: Score dropped"} {
+		if !strings.Contains(rendered.Text, want) {
+			t.Errorf("text does not contain %q:\n%s", want, rendered.Text)
+		}
+	}
+}
+
+func TestBuildMessage(t *testing.T) {
+	rendered := &models.RenderedDigest{Subject: "Audit digest: 8 Jan – 15 Jan", Text: "plain", HTML: "<p>html</p>"}
+	msg, err := buildMessage("audit@example.com", []string{"a@example.com", "b@example.com"}, rendered, time.Now())
+	if err != nil {
+		t.Fatalf("buildMessage() error = %v", err)
+	}
+	for _, want := range []string{"To: a@example.com, b@example.com", "multipart/alternative", "text/plain", "text/html", "=?utf-8?q?"} {
+		if !strings.Contains(string(msg), want) {
+			t.Errorf("message does not contain %q", want)
+		}
+	}
+}
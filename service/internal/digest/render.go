@@ -0,0 +1,111 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// templateFuncs are shared by the HTML and text templates
+var templateFuncs = map[string]interface{}{
+	"score": func(v float64) string { return fmt.Sprintf("%.1f", v) },
+	// Relevance accuracy is a fraction
+	"percent": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+	"change": func(v *float64) string {
+		if v == nil {
+			return "n/a"
+		}
+		return fmt.Sprintf("%+.1f", *v)
+	},
+	"scores": func(values []float64) string {
+		formatted := make([]string, len(values))
+		for i, v := range values {
+			formatted[i] = fmt.Sprintf("%.0f", v)
+		}
+		return strings.Join(formatted, " → ")
+	},
+	"period": period,
+}
+
+// period describes the date range a digest covers
+func period(d models.Digest) string {
+	return d.From.Format("2 Jan 2006") + " – " + d.To.Format("2 Jan 2006")
+}
+
+const htmlDigest = `<!DOCTYPE html>
+<html>
+<body style="font-family: -apple-system, Helvetica, Arial, sans-serif; color: #1d1c1d; max-width: 680px;">
+<h1 style="font-size: 20px;">Audit digest: {{period .Digest}}</h1>
+{{if not .Digest.Personas}}<p>No benchmarks were completed in this period.</p>{{end}}
+{{range .Digest.Personas}}
+<h2 style="font-size: 16px; margin-top: 24px;">{{.PersonaName}}</h2>
+<table style="border-collapse: collapse;">
+<tr><td style="padding: 2px 12px 2px 0;">Benchmarks</td><td>{{.Benchmarks}}</td></tr>
+<tr><td style="padding: 2px 12px 2px 0;">Average quality</td><td>{{score .AverageQualityScore}} ({{change .QualityChange}} vs previous period)</td></tr>
+<tr><td style="padding: 2px 12px 2px 0;">Latest quality</td><td>{{score .LatestQualityScore}}</td></tr>
+<tr><td style="padding: 2px 12px 2px 0;">Relevance accuracy</td><td>{{percent .AverageRelevanceAccuracy}}</td></tr>
+<tr><td style="padding: 2px 12px 2px 0;">Trend</td><td>{{scores .QualityScores}}</td></tr>
+</table>
+{{if .WorstItems}}<h3 style="font-size: 14px;">Worst items</h3>
+<ul>{{range .WorstItems}}
+<li><strong>{{.QualityRating}}</strong> — {{if $.DashboardURL}}<a href="{{$.DashboardURL}}/run/{{.RunID}}">{{.ItemID}}</a>{{else}}{{.ItemID}}{{end}}{{if .Explanation}}: {{.Explanation}}{{end}}</li>{{end}}
+</ul>{{end}}
+{{end}}
+<h2 style="font-size: 16px; margin-top: 24px;">Open alerts ({{len .Digest.OpenAlerts}})</h2>
+{{if .Digest.OpenAlerts}}<ul>{{range .Digest.OpenAlerts}}
+<li><strong>{{.Severity}}</strong> {{.PersonaName}}: {{.Message}} ({{.Status}})</li>{{end}}
+</ul>{{else}}<p>None.</p>{{end}}
+</body>
+</html>
+`
+
+const textDigest = `Audit digest: {{period .Digest}}
+{{if not .Digest.Personas}}
+No benchmarks were completed in this period.
+{{end}}{{range .Digest.Personas}}
+== {{.PersonaName}} ==
+Benchmarks:         {{.Benchmarks}}
+Average quality:    {{score .AverageQualityScore}} ({{change .QualityChange}} vs previous period)
+Latest quality:     {{score .LatestQualityScore}}
+Relevance accuracy: {{percent .AverageRelevanceAccuracy}}
+Trend:              {{scores .QualityScores}}
+{{if .WorstItems}}Worst items:
+{{range .WorstItems}}  - {{.QualityRating}}: {{.ItemID}} (run {{.RunID}}){{if .Explanation}}
+    {{.Explanation}}{{end}}
+{{end}}{{end}}{{end}}
+Open alerts ({{len .Digest.OpenAlerts}}):
+{{range .Digest.OpenAlerts}}  - [{{.Severity}}] {{.PersonaName}}: {{.Message}} ({{.Status}})
+{{else}}  None.
+{{end}}`
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest").Funcs(templateFuncs).Parse(htmlDigest))
+	textTemplate = texttemplate.Must(texttemplate.New("digest").Funcs(templateFuncs).Parse(textDigest))
+)
+
+// render produces the email subject and HTML and text bodies for a digest
+func render(digest models.Digest, dashboardURL string) (*models.RenderedDigest, error) {
+	data := struct {
+		Digest       models.Digest
+		DashboardURL string
+	}{digest, strings.TrimRight(dashboardURL, "/")}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render HTML digest: %w", err)
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render text digest: %w", err)
+	}
+
+	return &models.RenderedDigest{
+		Subject: "Audit digest: " + period(digest),
+		HTML:    html.String(),
+		Text:    text.String(),
+		Digest:  digest,
+	}, nil
+}
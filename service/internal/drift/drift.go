@@ -0,0 +1,259 @@
+package drift
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/alerting"
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// Metric names in drift reports
+const (
+	MetricRelevantRatio     = "relevant_ratio"
+	MetricSummaryLength     = "mean_summary_length"
+	MetricProcessingTime    = "total_processing_time"
+	MetricSuccessRate       = "success_rate"
+	MetricItemCount         = "item_count"
+	MetricSummaryLengthDist = "summary_length_distribution"
+)
+
+// minBaselineRuns is the fewest baseline runs a meaningful test needs
+const minBaselineRuns = internal.MinDriftBaselineRuns
+
+// runSample holds the characteristics of a single run
+type runSample struct {
+	relevantRatio  float64
+	summaryLength  float64 // Mean summary length in characters
+	processingTime float64
+	successRate    float64
+	itemCount      float64
+	summaryLengths []float64
+}
+
+// sampleRun extracts the characteristics tracked for drift from a run
+func sampleRun(run models.PersistedRunData) runSample {
+	sample := runSample{
+		processingTime: float64(run.TotalProcessingTime),
+		successRate:    run.SuccessRate,
+		itemCount:      float64(len(run.EntrySummaries)),
+	}
+
+	relevant := 0
+	for _, summary := range run.EntrySummaries {
+		if summary.Results.IsRelevant {
+			relevant++
+		}
+		if summary.Results.Summary != "" {
+			sample.summaryLengths = append(sample.summaryLengths, float64(len([]rune(summary.Results.Summary))))
+		}
+	}
+	if len(run.EntrySummaries) > 0 {
+		sample.relevantRatio = float64(relevant) / float64(len(run.EntrySummaries))
+	}
+	sample.summaryLength, _ = meanStdDev(sample.summaryLengths)
+	return sample
+}
+
+// analyse tests the latest recentRuns runs against the baselineRuns before them. runs must be oldest first.
+func analyse(personaName string, runs []models.PersistedRunData, baselineRuns, recentRuns int) models.DriftReport {
+	report := models.DriftReport{PersonaName: personaName, GeneratedAt: time.Now()}
+
+	if len(runs) < minBaselineRuns+1 {
+		report.Message = fmt.Sprintf("At least %d runs are needed, found %d", minBaselineRuns+1, len(runs))
+		return report
+	}
+	recentRuns = min(recentRuns, len(runs)-minBaselineRuns)
+	recent := runs[len(runs)-recentRuns:]
+	baseline := runs[max(0, len(runs)-recentRuns-baselineRuns) : len(runs)-recentRuns]
+	report.BaselineRuns = len(baseline)
+	report.RecentRuns = len(recent)
+
+	baselineSamples := make([]runSample, len(baseline))
+	for i, run := range baseline {
+		baselineSamples[i] = sampleRun(run)
+	}
+	recentSamples := make([]runSample, len(recent))
+	for i, run := range recent {
+		recentSamples[i] = sampleRun(run)
+	}
+
+	scalar := []struct {
+		name  string
+		value func(runSample) float64
+	}{
+		{MetricRelevantRatio, func(s runSample) float64 { return s.relevantRatio }},
+		{MetricSummaryLength, func(s runSample) float64 { return s.summaryLength }},
+		{MetricProcessingTime, func(s runSample) float64 { return s.processingTime }},
+		{MetricSuccessRate, func(s runSample) float64 { return s.successRate }},
+		{MetricItemCount, func(s runSample) float64 { return s.itemCount }},
+	}
+	for _, metric := range scalar {
+		baselineValues := values(baselineSamples, metric.value)
+		recentValues := values(recentSamples, metric.value)
+		baselineMean, _ := meanStdDev(baselineValues)
+		recentMean, _ := meanStdDev(recentValues)
+		statistic := cusum(baselineValues, recentValues)
+		report.Metrics = append(report.Metrics, models.DriftMetric{
+			Name:         metric.name,
+			Method:       "cusum",
+			BaselineMean: baselineMean,
+			RecentMean:   recentMean,
+			Statistic:    statistic,
+			Threshold:    cusumThreshold,
+			Drifted:      statistic > cusumThreshold,
+		})
+	}
+
+	// The whole distribution of summary lengths can shift without the mean moving much
+	var baselineLengths, recentLengths []float64
+	for _, s := range baselineSamples {
+		baselineLengths = append(baselineLengths, s.summaryLengths...)
+	}
+	for _, s := range recentSamples {
+		recentLengths = append(recentLengths, s.summaryLengths...)
+	}
+	if len(baselineLengths) > 0 && len(recentLengths) > 0 {
+		distance, pValue := ksTest(baselineLengths, recentLengths)
+		baselineMean, _ := meanStdDev(baselineLengths)
+		recentMean, _ := meanStdDev(recentLengths)
+		report.Metrics = append(report.Metrics, models.DriftMetric{
+			Name:         MetricSummaryLengthDist,
+			Method:       "ks",
+			BaselineMean: baselineMean,
+			RecentMean:   recentMean,
+			Statistic:    distance,
+			Threshold:    ksAlpha,
+			PValue:       &pValue,
+			Drifted:      pValue < ksAlpha,
+		})
+	}
+
+	for _, metric := range report.Metrics {
+		if metric.Drifted {
+			report.Drifted = true
+		}
+	}
+	return report
+}
+
+// values extracts one characteristic from each sample
+func values(samples []runSample, value func(runSample) float64) []float64 {
+	out := make([]float64, len(samples))
+	for i, s := range samples {
+		out[i] = value(s)
+	}
+	return out
+}
+
+// Service detects drift in persona runs and raises it through the alerting service
+type Service struct {
+	baselineRuns int
+	recentRuns   int
+	alerts       *alerting.Service
+}
+
+// NewService creates a drift detector that checks each persona when one of its runs is submitted
+func NewService(spec *internal.Specification, alerts *alerting.Service, bus *events.Bus) *Service {
+	s := &Service{
+		baselineRuns: spec.DriftBaselineRuns,
+		recentRuns:   spec.DriftRecentRuns,
+		alerts:       alerts,
+	}
+	if bus != nil {
+		bus.Subscribe(events.RunIngested, s.handleRunIngested)
+	}
+	return s
+}
+
+// handleRunIngested checks the persona of a submitted run for drift
+func (s *Service) handleRunIngested(event events.Event) {
+	run, ok := event.Data.(models.RunMetadata)
+	if !ok {
+		log.Printf("Unexpected %s event data: %T", event.Type, event.Data)
+		return
+	}
+	if _, err := s.Check(run.PersonaName); err != nil {
+		log.Printf("Error checking persona %s for drift: %v", run.PersonaName, err)
+	}
+}
+
+// Report analyses a persona's runs without raising alerts
+func (s *Service) Report(personaName string) (*models.DriftReport, error) {
+	runs, err := s.personaRuns(personaName)
+	if err != nil {
+		return nil, err
+	}
+	report := analyse(personaName, runs, s.baselineRuns, s.recentRuns)
+	return &report, nil
+}
+
+// Check analyses a persona's runs and raises an alert for each drifted metric
+func (s *Service) Check(personaName string) (*models.DriftReport, error) {
+	runs, err := s.personaRuns(personaName)
+	if err != nil {
+		return nil, err
+	}
+	report := analyse(personaName, runs, s.baselineRuns, s.recentRuns)
+
+	latestRunID := ""
+	if len(runs) > 0 {
+		latestRunID = runs[len(runs)-1].RunID
+	}
+	for _, metric := range report.Metrics {
+		if !metric.Drifted {
+			continue
+		}
+		alert := models.Alert{
+			Rule:        "drift_" + metric.Name,
+			Severity:    models.AlertSeverityWarning,
+			PersonaName: personaName,
+			RunID:       latestRunID,
+			Message: fmt.Sprintf("%s for %s has drifted: recent mean %.2f against a baseline of %.2f over %d run(s) (%s statistic %.3f)",
+				metric.Name, personaName, metric.RecentMean, metric.BaselineMean, report.BaselineRuns, metric.Method, metric.Statistic),
+			Value:     metric.Statistic,
+			Threshold: metric.Threshold,
+		}
+		if _, _, err := s.alerts.Raise(alert); err != nil {
+			return &report, err
+		}
+	}
+	return &report, nil
+}
+
+// personaRuns loads the persona's most recent runs covered by the baseline and recent windows, oldest first
+func (s *Service) personaRuns(personaName string) ([]models.PersistedRunData, error) {
+	all, err := storage.ListRunMetadata(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	var metadata []models.RunMetadata
+	for _, run := range all {
+		if run.PersonaName == personaName {
+			metadata = append(metadata, run)
+		}
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].RunDate.Before(metadata[j].RunDate)
+	})
+	if window := s.baselineRuns + s.recentRuns; len(metadata) > window {
+		metadata = metadata[len(metadata)-window:]
+	}
+
+	runs := make([]models.PersistedRunData, 0, len(metadata))
+	for _, meta := range metadata {
+		run, err := storage.GetRunData(meta.ID)
+		if err != nil {
+			log.Printf("Error loading run %s for drift detection: %v", meta.ID, err)
+			continue
+		}
+		runs = append(runs, *run)
+	}
+	return runs, nil
+}
@@ -0,0 +1,110 @@
+package drift
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	anpmodels "github.com/bakkerme/ai-news-processor/models"
+)
+
+// testRun builds a run of items where the first relevant items are relevant and every summary has summaryLength characters
+func testRun(items, relevant, summaryLength int, processingTime int64) models.PersistedRunData {
+	run := models.PersistedRunData{}
+	run.TotalProcessingTime = processingTime
+	run.SuccessRate = 1
+	for i := 0; i < items; i++ {
+		run.EntrySummaries = append(run.EntrySummaries, anpmodels.EntrySummary{
+			Results: anpmodels.Item{
+				Summary:    strings.Repeat("x", summaryLength+i%3),
+				IsRelevant: i < relevant,
+			},
+		})
+	}
+	return run
+}
+
+func TestAnalyse(t *testing.T) {
+	stable := func(i int) models.PersistedRunData {
+		return testRun(10, 5+i%2, 100+i%5, int64(1000+10*(i%3)))
+	}
+
+	tests := []struct {
+		name         string
+		runs         func() []models.PersistedRunData
+		baselineRuns int
+		recentRuns   int
+		wantDrifted  []string
+		wantMessage  bool
+	}{
+		{
+			name: "too few runs",
+			runs: func() []models.PersistedRunData {
+				return []models.PersistedRunData{stable(0), stable(1)}
+			},
+			wantMessage: true,
+		},
+		{
+			name: "stable persona",
+			runs: func() []models.PersistedRunData {
+				var runs []models.PersistedRunData
+				for i := 0; i < 15; i++ {
+					runs = append(runs, stable(i))
+				}
+				return runs
+			},
+		},
+		{
+			name: "summaries grow and relevance collapses",
+			runs: func() []models.PersistedRunData {
+				var runs []models.PersistedRunData
+				for i := 0; i < 10; i++ {
+					runs = append(runs, stable(i))
+				}
+				for i := 0; i < 5; i++ {
+					runs = append(runs, testRun(10, 1, 400, int64(1000+10*(i%3))))
+				}
+				return runs
+			},
+			wantDrifted: []string{MetricRelevantRatio, MetricSummaryLength, MetricSummaryLengthDist},
+		},
+		{
+			name: "smallest valid windows",
+			runs: func() []models.PersistedRunData {
+				var runs []models.PersistedRunData
+				for i := 0; i < minBaselineRuns+1; i++ {
+					runs = append(runs, testRun(10, 5, 100, 1000))
+				}
+				return runs
+			},
+			baselineRuns: minBaselineRuns,
+			recentRuns:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baselineRuns, recentRuns := 10, 5
+			if tt.baselineRuns > 0 {
+				baselineRuns, recentRuns = tt.baselineRuns, tt.recentRuns
+			}
+			report := analyse("persona", tt.runs(), baselineRuns, recentRuns)
+			if (report.Message != "") != tt.wantMessage {
+				t.Fatalf("analyse() message = %q, want message %v", report.Message, tt.wantMessage)
+			}
+
+			var drifted []string
+			for _, metric := range report.Metrics {
+				if metric.Drifted {
+					drifted = append(drifted, metric.Name)
+				}
+			}
+			if strings.Join(drifted, ",") != strings.Join(tt.wantDrifted, ",") {
+				t.Errorf("analyse() drifted metrics = %v, want %v", drifted, tt.wantDrifted)
+			}
+			if report.Drifted != (len(tt.wantDrifted) > 0) {
+				t.Errorf("analyse() drifted = %v, want %v", report.Drifted, len(tt.wantDrifted) > 0)
+			}
+		})
+	}
+}
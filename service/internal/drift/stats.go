@@ -0,0 +1,102 @@
+package drift
+
+import (
+	"math"
+	"sort"
+)
+
+// CUSUM parameters in baseline standard deviations: shifts smaller than cusumSlack are ignored,
+// and drift is flagged once the accumulated shift exceeds cusumThreshold
+const (
+	cusumSlack     = 0.5
+	cusumThreshold = 4.0
+)
+
+// ksAlpha is the significance level for the KS test
+const ksAlpha = 0.01
+
+// meanStdDev returns the mean and sample standard deviation of values
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+// cusum runs a two-sided tabular CUSUM over recent, standardised against the baseline, and
+// returns the largest accumulated shift in standard deviations. A constant baseline is given a
+// standard deviation of 1% of its mean so that any real change still registers.
+func cusum(baseline, recent []float64) float64 {
+	mean, sd := meanStdDev(baseline)
+	if sd == 0 {
+		sd = math.Max(math.Abs(mean)*0.01, 1e-9)
+	}
+
+	var high, low, peak float64
+	for _, v := range recent {
+		z := (v - mean) / sd
+		high = math.Max(0, high+z-cusumSlack)
+		low = math.Max(0, low-z-cusumSlack)
+		peak = math.Max(peak, math.Max(high, low))
+	}
+	return peak
+}
+
+// ksTest runs a two-sample Kolmogorov-Smirnov test and returns the distance between the
+// empirical distributions and its asymptotic p-value
+func ksTest(a, b []float64) (float64, float64) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, 1
+	}
+	a = append([]float64(nil), a...)
+	b = append([]float64(nil), b...)
+	sort.Float64s(a)
+	sort.Float64s(b)
+
+	var d float64
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		x := math.Min(a[i], b[j])
+		for i < len(a) && a[i] <= x {
+			i++
+		}
+		for j < len(b) && b[j] <= x {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/float64(len(a))-float64(j)/float64(len(b))))
+	}
+
+	n := float64(len(a)) * float64(len(b)) / float64(len(a)+len(b))
+	en := math.Sqrt(n)
+	return d, kolmogorovQ((en + 0.12 + 0.11/en) * d)
+}
+
+// kolmogorovQ is the complementary cumulative Kolmogorov distribution
+func kolmogorovQ(lambda float64) float64 {
+	if lambda < 1e-3 {
+		return 1
+	}
+	var sum float64
+	sign := 1.0
+	for j := 1; j <= 100; j++ {
+		term := sign * math.Exp(-2*float64(j*j)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-10 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Min(1, math.Max(0, 2*sum))
+}
@@ -0,0 +1,92 @@
+package drift
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCUSUM(t *testing.T) {
+	baseline := []float64{10, 11, 9, 10, 12, 8, 10, 11, 9, 10}
+
+	tests := []struct {
+		name    string
+		recent  []float64
+		drifted bool
+	}{
+		{name: "in control", recent: []float64{10, 11, 9, 10, 10}},
+		{name: "single outlier within slack", recent: []float64{12, 10, 10}},
+		{name: "sustained upward shift", recent: []float64{13, 13, 14, 13, 14}, drifted: true},
+		{name: "sustained downward shift", recent: []float64{7, 7, 6, 7, 6}, drifted: true},
+		{name: "large single jump", recent: []float64{30}, drifted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cusum(baseline, tt.recent)
+			if (got > cusumThreshold) != tt.drifted {
+				t.Errorf("cusum() = %.2f, want drifted %v", got, tt.drifted)
+			}
+		})
+	}
+}
+
+func TestCUSUMConstantBaseline(t *testing.T) {
+	baseline := []float64{1, 1, 1, 1, 1}
+	if got := cusum(baseline, []float64{1, 1}); got != 0 {
+		t.Errorf("cusum() of unchanged series = %v, want 0", got)
+	}
+	if got := cusum(baseline, []float64{0.9, 0.9}); got <= cusumThreshold {
+		t.Errorf("cusum() = %v, want a drop from a constant baseline to drift", got)
+	}
+}
+
+func TestKSTest(t *testing.T) {
+	uniform := func(n int, offset float64) []float64 {
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = offset + float64(i)
+		}
+		return values
+	}
+
+	tests := []struct {
+		name     string
+		a, b     []float64
+		wantD    float64
+		wantDiff bool // p-value below ksAlpha
+	}{
+		{name: "identical samples", a: uniform(100, 0), b: uniform(100, 0), wantD: 0},
+		{name: "disjoint samples", a: uniform(50, 0), b: uniform(50, 100), wantD: 1, wantDiff: true},
+		{name: "half shifted", a: uniform(200, 0), b: uniform(200, 100), wantD: 0.5, wantDiff: true},
+		{name: "empty sample", a: nil, b: uniform(10, 0), wantD: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, p := ksTest(tt.a, tt.b)
+			if math.Abs(d-tt.wantD) > 1e-9 {
+				t.Errorf("ksTest() distance = %v, want %v", d, tt.wantD)
+			}
+			if (p < ksAlpha) != tt.wantDiff {
+				t.Errorf("ksTest() p-value = %v, want below %v: %v", p, ksAlpha, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestKolmogorovQ(t *testing.T) {
+	// Reference values of the Kolmogorov distribution
+	tests := []struct {
+		lambda float64
+		want   float64
+	}{
+		{lambda: 0, want: 1},
+		{lambda: 1.36, want: 0.0494},
+		{lambda: 1.63, want: 0.0098},
+	}
+	for _, tt := range tests {
+		if got := kolmogorovQ(tt.lambda); math.Abs(got-tt.want) > 1e-3 {
+			t.Errorf("kolmogorovQ(%v) = %v, want %v", tt.lambda, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Event types published by the service
+const (
+	RunIngested        = "run.ingested"
+	BenchmarkCompleted = "benchmark.completed"
+	BenchmarkFailed    = "benchmark.failed"
+	AlertRaised        = "alert.raised"
+)
+
+// Types lists every event type, in lifecycle order
+var Types = []string{RunIngested, BenchmarkCompleted, BenchmarkFailed, AlertRaised}
+
+// Event is a notification that something happened in the service
+type Event struct {
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// Handler reacts to an event
+type Handler func(Event)
+
+// Bus delivers published events to subscribers in-process.
+// A nil *Bus is valid and discards everything published to it.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	wg       sync.WaitGroup
+}
+
+// NewBus creates an empty event bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers a handler for an event type
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish delivers an event to each subscriber in its own goroutine, so slow subscribers never block the publisher
+func (b *Bus) Publish(eventType string, data interface{}) {
+	if b == nil {
+		return
+	}
+
+	event := Event{Type: eventType, Timestamp: time.Now(), Data: data}
+	b.mu.RLock()
+	handlers := b.handlers[eventType]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.wg.Add(1)
+		go func(h Handler) {
+			defer b.wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Recovered from panic in %s event handler: %v", event.Type, r)
+				}
+			}()
+			h(event)
+		}(handler)
+	}
+}
+
+// Wait blocks until every handler started so far has returned
+func (b *Bus) Wait() {
+	if b == nil {
+		return
+	}
+	b.wg.Wait()
+}
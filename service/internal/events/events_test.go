@@ -0,0 +1,42 @@
+package events
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBusDeliversToSubscribersOfType(t *testing.T) {
+	bus := NewBus()
+
+	var mu sync.Mutex
+	var received []string
+	record := func(name string) Handler {
+		return func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, name+":"+e.Data.(string))
+		}
+	}
+	bus.Subscribe(BenchmarkCompleted, record("a"))
+	bus.Subscribe(BenchmarkCompleted, record("b"))
+	bus.Subscribe(AlertRaised, record("c"))
+	bus.Subscribe(BenchmarkCompleted, func(Event) { panic("handler failure") })
+
+	bus.Publish(BenchmarkCompleted, "x")
+	bus.Wait()
+
+	if len(received) != 2 {
+		t.Fatalf("received = %v, want deliveries to a and b only", received)
+	}
+	for _, r := range received {
+		if r != "a:x" && r != "b:x" {
+			t.Errorf("unexpected delivery %q", r)
+		}
+	}
+}
+
+func TestNilBusDiscardsEvents(t *testing.T) {
+	var bus *Bus
+	bus.Publish(BenchmarkCompleted, nil)
+	bus.Wait()
+}
@@ -16,9 +16,10 @@ type PersistedRunData struct {
 // RunResponse is the response after submitting run data.
 // Based on #/components/schemas/RunResponse
 type RunResponse struct {
-	ID      string `json:"id"`
-	Status  string `json:"status"` // e.g., received, processing, completed, failed
-	Message string `json:"message"`
+	ID          string `json:"id"`
+	Status      string `json:"status"` // e.g., stored, benchmark_queued
+	Message     string `json:"message"`
+	BenchmarkID string `json:"benchmarkId,omitempty"` // Set when a benchmark was queued automatically
 }
 
 // Error represents a generic error response.
@@ -48,13 +49,201 @@ type BenchmarkResponse struct {
 	EstimatedCompletionTime time.Time `json:"estimatedCompletionTime,omitempty"` // Note: Spec says string, format: date-time.
 }
 
+// CreateBenchmarkRequest holds the optional settings for a new benchmark.
+// The request body may be omitted entirely.
+type CreateBenchmarkRequest struct {
+	BypassCache bool             `json:"bypassCache,omitempty"` // Send every item to the judges even if a cached response exists
+	Budget      *BenchmarkBudget `json:"budget,omitempty"`      // Overrides the configured default limits field by field
+	Sampling    *SamplingSpec    `json:"sampling,omitempty"`    // Judge a sample of the run instead of every item
+}
+
+// SamplingSpec selects a sample of a run's items. Exactly one of Size and Percentage is set.
+type SamplingSpec struct {
+	Size       int     `json:"size,omitempty"`       // Fixed number of items
+	Percentage float64 `json:"percentage,omitempty"` // Share of items, greater than 0 and at most 100
+	StratifyBy string  `json:"stratifyBy,omitempty"` // "isRelevant" keeps the run's mix of relevant and irrelevant items
+	Seed       int64   `json:"seed,omitempty"`       // Zero picks a random seed, which is recorded for reproducibility
+}
+
+// SamplingStratum reports how one stratum of a run was sampled.
+type SamplingStratum struct {
+	Name       string `json:"name"`
+	Population int    `json:"population"`
+	Sampled    int    `json:"sampled"`
+}
+
+// SamplingInfo describes the sample a benchmark was computed from.
+type SamplingInfo struct {
+	Spec           SamplingSpec      `json:"spec"` // Seed is always set
+	PopulationSize int               `json:"populationSize"`
+	SampleSize     int               `json:"sampleSize"`
+	Strata         []SamplingStratum `json:"strata,omitempty"`
+}
+
+// ConfidenceInterval is a bootstrap confidence interval around a score.
+type ConfidenceInterval struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Level float64 `json:"level"` // e.g. 0.95
+}
+
+// BenchmarkBudget limits how much a single benchmark may spend. Zero means no limit.
+type BenchmarkBudget struct {
+	MaxItems           int     `json:"maxItems,omitempty"`           // Items sent to the judges
+	MaxTokens          int64   `json:"maxTokens,omitempty"`          // Judge and embedding tokens
+	MaxCost            float64 `json:"maxCost,omitempty"`            // Estimated USD
+	MaxDurationSeconds int     `json:"maxDurationSeconds,omitempty"` // Wall-clock time
+}
+
+// Benchmark states recorded on BenchmarkResults
+const (
+	BenchmarkStatusCompleted      = "completed"
+	BenchmarkStatusFailed         = "failed"
+	BenchmarkStatusBudgetExceeded = "budget_exceeded" // Partial results; the remaining items were skipped
+)
+
+// Item states recorded on each EvaluationResult
+const (
+	ItemStatusEvaluated         = "evaluated"           // Judged successfully
+	ItemStatusJudgeError        = "judge_error"         // Every judge call failed
+	ItemStatusParseError        = "parse_error"         // A judge responded but the response was unusable
+	ItemStatusNoSource          = "no_source"           // No raw input could be matched to the item
+	ItemStatusMissingFromOutput = "missing_from_output" // Raw input with no processed item, scored Poor
+	ItemStatusSkipped           = "skipped"             // Not evaluated because the benchmark budget ran out
+)
+
 // EvaluationResult holds detailed evaluation for an item.
 // Based on #/components/schemas/EvaluationResult
 type EvaluationResult struct {
-	QualityRating        string `json:"qualityRating"` // Excellent, Good, Fair, Poor
-	QualityExplanation   string `json:"qualityExplanation"`
-	RelevanceCorrect     bool   `json:"relevanceCorrect"`
-	RelevanceExplanation string `json:"relevanceExplanation"`
+	QualityRating        string              `json:"qualityRating"` // Excellent, Good, Fair, Poor
+	QualityExplanation   string              `json:"qualityExplanation"`
+	RelevanceCorrect     bool                `json:"relevanceCorrect"`
+	RelevanceExplanation string              `json:"relevanceExplanation"`
+	JudgeVerdicts        []JudgeVerdict      `json:"judgeVerdicts,omitempty"` // Individual verdicts when a judge panel is used
+	Disagreement         float64             `json:"disagreement,omitempty"`  // Share of judges whose rating differs from the combined rating
+	Confidence           float64             `json:"confidence"`              // Share of all judge samples that agree with the combined rating
+	LowConfidence        bool                `json:"lowConfidence,omitempty"` // Confidence fell below the review threshold
+	Lexical              *LexicalMetrics     `json:"lexical,omitempty"`       // Judge-free metrics against the raw input
+	Hallucination        *HallucinationCheck `json:"hallucination,omitempty"` // Rule-based check of facts in the summary
+	Semantic             *SemanticSimilarity `json:"semantic,omitempty"`      // Embedding similarity, when an embedding model is configured
+	MatchMethod          string              `json:"matchMethod,omitempty"`   // How the item was paired with its raw input: structured or pattern
+	Status               string              `json:"status,omitempty"`        // One of the ItemStatus values
+	Error                string              `json:"error,omitempty"`         // Why the item could not be evaluated
+	EvaluatedAt          time.Time           `json:"evaluatedAt,omitempty"`
+	PreviousVerdicts     []EvaluationResult  `json:"previousVerdicts,omitempty"` // Earlier evaluations replaced by re-evaluation, oldest first
+	Usage                *ItemUsage          `json:"usage,omitempty"`
+	HumanAnnotations     []Annotation        `json:"humanAnnotations,omitempty"` // Attached when results are returned, never stored with them
+}
+
+// TokenUsage accumulates the tokens, latency and estimated cost of LLM requests.
+type TokenUsage struct {
+	Requests         int     `json:"requests"`
+	PromptTokens     int64   `json:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens"`
+	TotalTokens      int64   `json:"totalTokens"`
+	LatencyMs        int64   `json:"latencyMs"`     // Summed request latency, including retries
+	EstimatedCost    float64 `json:"estimatedCost"` // USD, from the configured price table
+}
+
+// ItemUsage is the usage attributable to evaluating a single item.
+type ItemUsage struct {
+	Judging   TokenUsage `json:"judging"`
+	Embedding TokenUsage `json:"embedding"`
+	LatencyMs int64      `json:"latencyMs"` // Wall-clock time to evaluate the item
+}
+
+// BenchmarkUsage totals the usage of a benchmark, including verdicts later replaced by re-evaluation.
+type BenchmarkUsage struct {
+	Total          TokenUsage            `json:"total"`
+	Phases         map[string]TokenUsage `json:"phases"` // judging or embedding
+	Models         map[string]TokenUsage `json:"models"`
+	UnpricedModels []string              `json:"unpricedModels,omitempty"` // Models used without a price, so cost is underestimated
+	DurationMs     int64                 `json:"durationMs"`               // Wall-clock time of the benchmark run
+}
+
+// UnsupportedSpan is a fact in a summary that could not be found in the source.
+type UnsupportedSpan struct {
+	Text string `json:"text"`
+	Kind string `json:"kind"` // number, version, url or entity
+}
+
+// HallucinationCheck records which extracted facts in a summary are unsupported by its source.
+type HallucinationCheck struct {
+	CheckedSpans     int               `json:"checkedSpans"`
+	UnsupportedSpans []UnsupportedSpan `json:"unsupportedSpans,omitempty"`
+}
+
+// SemanticSimilarity holds embedding-based cosine similarities for an item's summary.
+type SemanticSimilarity struct {
+	SourceSimilarity   float64 `json:"sourceSimilarity"`             // Summary against its raw input
+	CrossRunSimilarity float64 `json:"crossRunSimilarity,omitempty"` // Summary against the same item's summary in the previous run
+	PreviousRunID      string  `json:"previousRunId,omitempty"`
+}
+
+// SemanticSummary aggregates semantic similarity across a benchmark.
+type SemanticSummary struct {
+	MeanSourceSimilarity   float64 `json:"meanSourceSimilarity"`
+	MeanCrossRunSimilarity float64 `json:"meanCrossRunSimilarity,omitempty"`
+	ItemsCompared          int     `json:"itemsCompared"`           // Items with a source similarity
+	CrossRunItems          int     `json:"crossRunItems"`           // Items also present in the previous run
+	PreviousRunID          string  `json:"previousRunId,omitempty"` // Run used for cross-run similarity
+	EmbeddingModel         string  `json:"embeddingModel"`
+}
+
+// RougeScore holds precision, recall and F1 for a ROUGE variant.
+type RougeScore struct {
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+}
+
+// LexicalMetrics holds deterministic, judge-free metrics comparing a summary to its source.
+type LexicalMetrics struct {
+	Rouge1           RougeScore `json:"rouge1"`
+	Rouge2           RougeScore `json:"rouge2"`
+	RougeL           RougeScore `json:"rougeL"`
+	CompressionRatio float64    `json:"compressionRatio"` // Summary tokens divided by source tokens
+	SummaryLength    float64    `json:"summaryLength"`    // Summary length in tokens
+	SourceLength     float64    `json:"sourceLength"`     // Source length in tokens
+	NovelBigramRatio float64    `json:"novelBigramRatio"` // Share of summary bigrams absent from the source
+}
+
+// JudgeVerdict holds a single judge's evaluation of an item.
+type JudgeVerdict struct {
+	Judge                string      `json:"judge"`
+	Model                string      `json:"model"`
+	QualityRating        string      `json:"qualityRating,omitempty"`
+	QualityExplanation   string      `json:"qualityExplanation,omitempty"`
+	RelevanceCorrect     bool        `json:"relevanceCorrect"`
+	RelevanceExplanation string      `json:"relevanceExplanation,omitempty"`
+	Error                string      `json:"error,omitempty"`         // Set when the judge failed to produce a verdict
+	Status               string      `json:"status,omitempty"`        // judge_error or parse_error when Error is set
+	SampleRatings        []string    `json:"sampleRatings,omitempty"` // Ratings from each self-consistency sample
+	Usage                *TokenUsage `json:"usage,omitempty"`
+}
+
+// JudgeInfo identifies a judge used for a benchmark. Credentials are never stored.
+type JudgeInfo struct {
+	Name  string `json:"name"`
+	Model string `json:"model"`
+	URL   string `json:"url,omitempty"`
+}
+
+// JudgeSettings records how a benchmark was judged.
+type JudgeSettings struct {
+	Judges        []JudgeInfo `json:"judges"`
+	Aggregation   string      `json:"aggregation"` // majority or mean
+	Samples       int         `json:"samples,omitempty"`
+	Temperature   float64     `json:"temperature,omitempty"`   // Sampling temperature when Samples > 1
+	RubricVersion string      `json:"rubricVersion,omitempty"` // Version of the evaluation prompt the judges were given
+}
+
+// JudgeAgreement summarises inter-judge agreement across a benchmark.
+type JudgeAgreement struct {
+	QualityKappa   float64 `json:"qualityKappa"`   // Fleiss' kappa over quality ratings
+	RelevanceKappa float64 `json:"relevanceKappa"` // Fleiss' kappa over relevance verdicts
+	ItemsRated     int     `json:"itemsRated"`     // Items rated by every judge, used for kappa
+	UnanimousItems int     `json:"unanimousItems"` // Items where every judge gave the same quality rating
 }
 
 // BenchmarkResults contains the results of a benchmark evaluation.
@@ -73,6 +262,446 @@ type BenchmarkResults struct {
 	RawOutput           map[string]interface{}      `json:"rawOutput,omitempty"`
 	Judgement           string                      `json:"judgement,omitempty"`
 	FailureReason       string                      `json:"failureReason,omitempty"`
+	Judging             *JudgeSettings              `json:"judging,omitempty"`
+	JudgeAgreement      *JudgeAgreement             `json:"judgeAgreement,omitempty"` // Only set when more than one judge is used
+	LowConfidenceItems  []string                    `json:"lowConfidenceItems,omitempty"`
+	LexicalSummary      *LexicalMetrics             `json:"lexicalSummary,omitempty"` // Mean of the per-item lexical metrics
+	HallucinationRate   float64                     `json:"hallucinationRate"`        // Unsupported spans divided by checked spans
+	HallucinatedItems   []string                    `json:"hallucinatedItems,omitempty"`
+	SemanticSummary     *SemanticSummary            `json:"semanticSummary,omitempty"`
+	UnmatchedItems      []string                    `json:"unmatchedItems,omitempty"` // Processed items whose raw input could not be found; not scored
+	MatchDiagnostics    *MatchDiagnostics           `json:"matchDiagnostics,omitempty"`
+	EvaluatedItems      int                         `json:"evaluatedItems"`         // Items a judge rated successfully
+	ErroredItems        []string                    `json:"erroredItems,omitempty"` // Items excluded from scoring because they could not be evaluated
+	Coverage            float64                     `json:"coverage"`               // Percentage of TotalItems included in the scores
+	CacheHits           int                         `json:"cacheHits"`              // Judge responses served from the cache
+	CacheMisses         int                         `json:"cacheMisses"`            // Judge responses requested from the judges
+	CacheBypassed       bool                        `json:"cacheBypassed,omitempty"`
+	Usage               *BenchmarkUsage             `json:"usage,omitempty"`
+	Status              string                      `json:"status,omitempty"`         // One of the BenchmarkStatus values
+	Budget              *BenchmarkBudget            `json:"budget,omitempty"`         // Limits in effect for this benchmark
+	BudgetExceeded      string                      `json:"budgetExceeded,omitempty"` // Which limit stopped the benchmark
+	SkippedItems        []string                    `json:"skippedItems,omitempty"`
+	Sampling            *SamplingInfo               `json:"sampling,omitempty"`            // Set when only a sample of the run was judged
+	QualityScoreCI      *ConfidenceInterval         `json:"qualityScoreCI,omitempty"`      // Bootstrap interval for QualityScore
+	RelevanceAccuracyCI *ConfidenceInterval         `json:"relevanceAccuracyCI,omitempty"` // Bootstrap interval for RelevanceAccuracy
+}
+
+// MatchDiagnostics reports how processed items were paired with their raw input.
+// A rise in unmatched items usually means the upstream raw input format changed.
+type MatchDiagnostics struct {
+	Methods            map[string]int `json:"methods"`            // Matched items by method
+	UnmatchedItems     int            `json:"unmatchedItems"`     // Processed items with no raw input
+	DroppedItems       int            `json:"droppedItems"`       // Raw inputs with no processed item
+	UnidentifiedInputs int            `json:"unidentifiedInputs"` // Raw inputs with no processed item and no extractable ID
+	Patterns           []string       `json:"patterns,omitempty"` // Fallback ID patterns in use
+}
+
+// PairwiseJudgeVote is one judge's preference between two summaries of the same item.
+// Each judge sees both orderings so that position bias cancels out.
+type PairwiseJudgeVote struct {
+	Judge           string `json:"judge"`
+	Winner          string `json:"winner,omitempty"`      // head, base or tie
+	OrderConsistent bool   `json:"orderConsistent"`       // Whether both orderings picked the same winner
+	Explanation     string `json:"explanation,omitempty"` // Explanation from the first ordering
+	Error           string `json:"error,omitempty"`
+}
+
+// PairwiseVerdict holds the combined pairwise verdict for an item.
+type PairwiseVerdict struct {
+	Winner      string              `json:"winner"` // head, base or tie
+	Explanation string              `json:"explanation,omitempty"`
+	Votes       []PairwiseJudgeVote `json:"votes"`
+}
+
+// PairwiseResults contains the results of comparing the summaries of two runs.
+// Win, tie and loss counts are from the perspective of the head run.
+type PairwiseResults struct {
+	ComparisonID  string                     `json:"comparisonId"`
+	BaseRunID     string                     `json:"baseRunId"`
+	HeadRunID     string                     `json:"headRunId"`
+	PersonaName   string                     `json:"personaName"`
+	Timestamp     time.Time                  `json:"timestamp"`
+	TotalItems    int                        `json:"totalItems"`
+	Wins          int                        `json:"wins"`
+	Ties          int                        `json:"ties"`
+	Losses        int                        `json:"losses"`
+	WinRate       float64                    `json:"winRate"`
+	TieRate       float64                    `json:"tieRate"`
+	LossRate      float64                    `json:"lossRate"`
+	Verdicts      map[string]PairwiseVerdict `json:"verdicts"` // Map of item ID to pairwise verdict
+	OnlyInBase    []string                   `json:"onlyInBase,omitempty"`
+	OnlyInHead    []string                   `json:"onlyInHead,omitempty"`
+	FailedItems   []string                   `json:"failedItems,omitempty"` // Items no judge could compare
+	Judging       *JudgeSettings             `json:"judging,omitempty"`
+	FailureReason string                     `json:"failureReason,omitempty"`
+}
+
+// SignificanceTest is a two-sided permutation test of the difference between two benchmarks' mean scores.
+type SignificanceTest struct {
+	BaseMean     float64 `json:"baseMean"`
+	HeadMean     float64 `json:"headMean"`
+	Difference   float64 `json:"difference"` // Head minus base
+	PValue       float64 `json:"pValue"`
+	Significant  bool    `json:"significant"` // Whether PValue is below the significance level
+	Permutations int     `json:"permutations"`
+}
+
+// BenchmarkSignificance tests whether the scores of two benchmarks differ by more than chance.
+type BenchmarkSignificance struct {
+	BaseBenchmarkID   string            `json:"baseBenchmarkId"`
+	HeadBenchmarkID   string            `json:"headBenchmarkId"`
+	Alpha             float64           `json:"alpha"` // Significance level, e.g. 0.05
+	QualityScore      *SignificanceTest `json:"qualityScore,omitempty"`
+	RelevanceAccuracy *SignificanceTest `json:"relevanceAccuracy,omitempty"`
+}
+
+// RatingChange is an item whose quality rating differs between two benchmarks.
+type RatingChange struct {
+	ItemID     string  `json:"itemId"`
+	BaseRating string  `json:"baseRating"`
+	HeadRating string  `json:"headRating"`
+	ScoreDelta float64 `json:"scoreDelta"` // Head score minus base score
+}
+
+// RelevanceChange is an item whose relevance judgement became correct or incorrect between two benchmarks.
+type RelevanceChange struct {
+	ItemID      string `json:"itemId"`
+	BaseCorrect bool   `json:"baseCorrect"`
+	HeadCorrect bool   `json:"headCorrect"`
+}
+
+// BenchmarkComparison aligns the items of two benchmarks by ID and reports what changed from base to head.
+type BenchmarkComparison struct {
+	BaseBenchmarkID        string                 `json:"baseBenchmarkId"`
+	HeadBenchmarkID        string                 `json:"headBenchmarkId"`
+	BaseRunID              string                 `json:"baseRunId"`
+	HeadRunID              string                 `json:"headRunId"`
+	QualityScoreDelta      float64                `json:"qualityScoreDelta"`
+	RelevanceAccuracyDelta float64                `json:"relevanceAccuracyDelta"` // Difference of fractions, so 0.25 is 25 points
+	CoverageDelta          float64                `json:"coverageDelta"`
+	ComparedItems          int                    `json:"comparedItems"` // Items scored in both benchmarks
+	UnchangedItems         int                    `json:"unchangedItems"`
+	Improved               []RatingChange         `json:"improved,omitempty"`
+	Regressed              []RatingChange         `json:"regressed,omitempty"`
+	RelevanceFixed         []RelevanceChange      `json:"relevanceFixed,omitempty"`  // Incorrect in base, correct in head
+	RelevanceBroken        []RelevanceChange      `json:"relevanceBroken,omitempty"` // Correct in base, incorrect in head
+	OnlyInBase             []string               `json:"onlyInBase,omitempty"`
+	OnlyInHead             []string               `json:"onlyInHead,omitempty"`
+	NotCompared            []string               `json:"notCompared,omitempty"` // In both, but not scored in at least one
+	Significance           *BenchmarkSignificance `json:"significance,omitempty"`
+}
+
+// BenchmarkSchedule benchmarks the latest unbenchmarked run of a persona on a cron schedule.
+type BenchmarkSchedule struct {
+	ID              string     `json:"id"`
+	PersonaName     string     `json:"personaName"`
+	Cron            string     `json:"cron"` // Five-field cron expression or descriptor such as @daily, in server local time
+	Enabled         bool       `json:"enabled"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	NextRunAt       time.Time  `json:"nextRunAt"`
+	LastRunAt       *time.Time `json:"lastRunAt,omitempty"`
+	LastStatus      string     `json:"lastStatus,omitempty"` // benchmark_queued, no_new_run or failed
+	LastBenchmarkID string     `json:"lastBenchmarkId,omitempty"`
+	LastError       string     `json:"lastError,omitempty"`
+}
+
+// ScheduleRequest creates or updates a benchmark schedule. Unset fields are left unchanged on update.
+type ScheduleRequest struct {
+	PersonaName string `json:"personaName"`
+	Cron        string `json:"cron"`
+	Enabled     *bool  `json:"enabled,omitempty"` // Defaults to true on create
+}
+
+// Alert severities
+const (
+	AlertSeverityWarning  = "warning"
+	AlertSeverityCritical = "critical"
+)
+
+// Alert states
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+)
+
+// Alert records a detected quality regression.
+type Alert struct {
+	ID             string     `json:"id"`
+	Rule           string     `json:"rule"`     // Name of the rule that raised the alert
+	Severity       string     `json:"severity"` // warning or critical
+	Status         string     `json:"status"`   // open, acknowledged or resolved
+	PersonaName    string     `json:"personaName"`
+	BenchmarkID    string     `json:"benchmarkId,omitempty"`
+	RunID          string     `json:"runId,omitempty"`
+	Message        string     `json:"message"`
+	Value          float64    `json:"value"`     // Observed value that breached the rule
+	Threshold      float64    `json:"threshold"` // Limit the value was compared against
+	CreatedAt      time.Time  `json:"createdAt"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	AcknowledgedBy string     `json:"acknowledgedBy,omitempty"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty"`
+	ResolvedBy     string     `json:"resolvedBy,omitempty"`
+	Note           string     `json:"note,omitempty"`
+}
+
+// AlertActionRequest is the optional body for acknowledging or resolving an alert.
+type AlertActionRequest struct {
+	By   string `json:"by,omitempty"`
+	Note string `json:"note,omitempty"`
+}
+
+// Webhook delivery states
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Webhook types
+const (
+	WebhookTypeGeneric = "webhook" // Signed JSON event payload
+	WebhookTypeSlack   = "slack"   // Block Kit message for a Slack incoming webhook
+	WebhookTypeDiscord = "discord" // Embed message for a Discord webhook
+)
+
+// Webhook is an outbound notification target for service events.
+type Webhook struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	Type      string    `json:"type"` // webhook, slack or discord
+	URL       string    `json:"url"`
+	Events    []string  `json:"events,omitempty"` // Event types to deliver; empty means all
+	Secret    string    `json:"secret,omitempty"` // HMAC key for X-ANAS-Signature, only returned on create
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WebhookRequest creates or updates a webhook. Unset fields are left unchanged on update.
+type WebhookRequest struct {
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type,omitempty"` // Defaults to webhook on create
+	URL     string   `json:"url,omitempty"`
+	Events  []string `json:"events,omitempty"`
+	Secret  string   `json:"secret,omitempty"`  // Generated on create when empty
+	Enabled *bool    `json:"enabled,omitempty"` // Defaults to true on create
+}
+
+// DeliveryAttempt is a single attempt to deliver an event to a webhook.
+type DeliveryAttempt struct {
+	Attempt    int       `json:"attempt"`
+	Timestamp  time.Time `json:"timestamp"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// WebhookDelivery records the delivery of one event to one webhook.
+type WebhookDelivery struct {
+	ID          string            `json:"id"`
+	WebhookID   string            `json:"webhookId"`
+	EventType   string            `json:"eventType"`
+	Status      string            `json:"status"` // pending, delivered or failed
+	Attempts    []DeliveryAttempt `json:"attempts"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	CompletedAt *time.Time        `json:"completedAt,omitempty"`
+}
+
+// DigestItem is a poorly rated item highlighted in a digest.
+type DigestItem struct {
+	BenchmarkID   string `json:"benchmarkId"`
+	RunID         string `json:"runId"`
+	ItemID        string `json:"itemId"`
+	QualityRating string `json:"qualityRating"`
+	Explanation   string `json:"explanation,omitempty"`
+}
+
+// PersonaDigest summarises a persona's benchmarks over a digest period.
+type PersonaDigest struct {
+	PersonaName              string       `json:"personaName"`
+	Benchmarks               int          `json:"benchmarks"`
+	QualityScores            []float64    `json:"qualityScores"` // Oldest first
+	AverageQualityScore      float64      `json:"averageQualityScore"`
+	LatestQualityScore       float64      `json:"latestQualityScore"`
+	AverageRelevanceAccuracy float64      `json:"averageRelevanceAccuracy"` // Fraction, like BenchmarkResults.RelevanceAccuracy
+	QualityChange            *float64     `json:"qualityChange,omitempty"`  // Average compared with the previous period of the same length
+	WorstItems               []DigestItem `json:"worstItems,omitempty"`
+}
+
+// Digest summarises audit results over a period.
+type Digest struct {
+	From       time.Time       `json:"from"`
+	To         time.Time       `json:"to"`
+	Personas   []PersonaDigest `json:"personas"`
+	OpenAlerts []Alert         `json:"openAlerts"`
+}
+
+// RenderedDigest is a digest rendered for email.
+type RenderedDigest struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+	Digest  Digest `json:"digest"`
+}
+
+// DriftMetric is the drift test result for one run characteristic.
+type DriftMetric struct {
+	Name         string   `json:"name"`
+	Method       string   `json:"method"` // cusum or ks
+	BaselineMean float64  `json:"baselineMean"`
+	RecentMean   float64  `json:"recentMean"`
+	Statistic    float64  `json:"statistic"` // CUSUM in baseline standard deviations, or the KS distance
+	Threshold    float64  `json:"threshold"`
+	PValue       *float64 `json:"pValue,omitempty"` // KS test only
+	Drifted      bool     `json:"drifted"`
+}
+
+// DriftReport compares a persona's recent runs against a baseline window of earlier runs.
+type DriftReport struct {
+	PersonaName  string        `json:"personaName"`
+	GeneratedAt  time.Time     `json:"generatedAt"`
+	BaselineRuns int           `json:"baselineRuns"`
+	RecentRuns   int           `json:"recentRuns"`
+	Metrics      []DriftMetric `json:"metrics,omitempty"`
+	Drifted      bool          `json:"drifted"`
+	Message      string        `json:"message,omitempty"` // Why no metrics were tested, if so
+}
+
+// GoldenItem is a source entry in a golden dataset with its expected outcome.
+type GoldenItem struct {
+	ID               string `json:"id"` // Matched against the item IDs of scored runs
+	Title            string `json:"title,omitempty"`
+	Source           string `json:"source,omitempty"` // The entry as given to the processor
+	ExpectedRelevant bool   `json:"expectedRelevant"`
+	ReferenceSummary string `json:"referenceSummary,omitempty"` // Optional human-written summary
+}
+
+// GoldenDataset is a named, fixed set of source entries with expected relevance labels.
+type GoldenDataset struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	PersonaName string       `json:"personaName,omitempty"` // Persona the labels were written for, if any
+	Items       []GoldenItem `json:"items"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+// GoldenDatasetRequest is the body for creating or updating a golden dataset. Items replace the
+// existing items when given.
+type GoldenDatasetRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	PersonaName string       `json:"personaName,omitempty"`
+	Items       []GoldenItem `json:"items,omitempty"`
+}
+
+// RelevanceScore is the confusion matrix and derived scores of predicted against expected relevance.
+type RelevanceScore struct {
+	TruePositives  int     `json:"truePositives"`
+	FalsePositives int     `json:"falsePositives"`
+	FalseNegatives int     `json:"falseNegatives"`
+	TrueNegatives  int     `json:"trueNegatives"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+	F1             float64 `json:"f1"`
+	Accuracy       float64 `json:"accuracy"`
+}
+
+// ReferenceSimilarity aggregates how closely a run's summaries match the reference summaries.
+type ReferenceSimilarity struct {
+	ItemsCompared      int        `json:"itemsCompared"`
+	Rouge1             RougeScore `json:"rouge1"`
+	Rouge2             RougeScore `json:"rouge2"`
+	RougeL             RougeScore `json:"rougeL"`
+	SemanticSimilarity *float64   `json:"semanticSimilarity,omitempty"` // Mean embedding similarity, when an embedding model is configured
+}
+
+// GoldenItemScore is the outcome of one golden item in a run.
+type GoldenItemScore struct {
+	ItemID             string      `json:"itemId"`
+	ExpectedRelevant   bool        `json:"expectedRelevant"`
+	PredictedRelevant  bool        `json:"predictedRelevant"`
+	Rouge1             *RougeScore `json:"rouge1,omitempty"` // Against the reference summary, if there is one
+	RougeL             *RougeScore `json:"rougeL,omitempty"`
+	SemanticSimilarity *float64    `json:"semanticSimilarity,omitempty"`
+}
+
+// GoldenScore is the result of scoring a run's outputs against a golden dataset.
+type GoldenScore struct {
+	DatasetID    string               `json:"datasetId"`
+	DatasetName  string               `json:"datasetName"`
+	RunID        string               `json:"runId"`
+	ScoredAt     time.Time            `json:"scoredAt"`
+	GoldenItems  int                  `json:"goldenItems"`
+	MatchedItems int                  `json:"matchedItems"`           // Golden items the run produced an output for
+	MissingItems []string             `json:"missingItems,omitempty"` // Golden items absent from the run
+	Relevance    RelevanceScore       `json:"relevance"`              // Over matched items only
+	Reference    *ReferenceSimilarity `json:"reference,omitempty"`    // Over matched items with a reference summary
+	Items        []GoldenItemScore    `json:"items"`
+}
+
+// Annotation is a human's verdict on an item in a run, stored separately from LLM evaluations.
+type Annotation struct {
+	ID               string    `json:"id"`
+	RunID            string    `json:"runId"`
+	ItemID           string    `json:"itemId"`
+	Annotator        string    `json:"annotator"`
+	QualityRating    string    `json:"qualityRating,omitempty"`    // Excellent, Good, Fair, Poor
+	RelevanceCorrect *bool     `json:"relevanceCorrect,omitempty"` // Whether the processor's IsRelevant flag was right
+	Notes            string    `json:"notes,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// AnnotationRequest is the body for creating or updating an annotation.
+type AnnotationRequest struct {
+	Annotator        string `json:"annotator"`
+	QualityRating    string `json:"qualityRating,omitempty"`
+	RelevanceCorrect *bool  `json:"relevanceCorrect,omitempty"`
+	Notes            string `json:"notes,omitempty"`
+}
+
+// CalibrationDisagreement is an item on which a judge and a human annotator disagreed.
+type CalibrationDisagreement struct {
+	RunID                 string `json:"runId"`
+	ItemID                string `json:"itemId"`
+	BenchmarkID           string `json:"benchmarkId"`
+	AnnotationID          string `json:"annotationId"`
+	Annotator             string `json:"annotator"`
+	HumanRating           string `json:"humanRating,omitempty"`
+	JudgeRating           string `json:"judgeRating,omitempty"`
+	RatingDistance        int    `json:"ratingDistance"` // Steps apart on the rating scale
+	HumanRelevanceCorrect *bool  `json:"humanRelevanceCorrect,omitempty"`
+	JudgeRelevanceCorrect bool   `json:"judgeRelevanceCorrect"`
+	RelevanceDisagrees    bool   `json:"relevanceDisagrees"`
+	Notes                 string `json:"notes,omitempty"`
+	JudgeExplanation      string `json:"judgeExplanation,omitempty"`
+}
+
+// JudgeCalibration compares one judge's verdicts under one rubric against human annotations.
+type JudgeCalibration struct {
+	Judge              string                    `json:"judge"`
+	Model              string                    `json:"model"`
+	RubricVersion      string                    `json:"rubricVersion"`
+	Ratings            []string                  `json:"ratings"`         // Row and column order of the confusion matrix
+	ConfusionMatrix    [][]int                   `json:"confusionMatrix"` // Rows are human ratings, columns the judge's
+	RatedPairs         int                       `json:"ratedPairs"`      // Verdicts where both gave a quality rating
+	ExactAgreement     float64                   `json:"exactAgreement"`
+	CohensKappa        float64                   `json:"cohensKappa"`
+	RelevancePairs     int                       `json:"relevancePairs"` // Verdicts where the human gave a relevance verdict
+	RelevanceAgreement float64                   `json:"relevanceAgreement"`
+	Disagreements      []CalibrationDisagreement `json:"disagreements,omitempty"` // Largest first
+}
+
+// CalibrationReport measures how closely each judge and rubric agree with human annotators.
+type CalibrationReport struct {
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Annotations int                `json:"annotations"` // Human annotations considered
+	Judges      []JudgeCalibration `json:"judges"`
 }
 
 // LogEntry represents a single log entry.
@@ -0,0 +1,203 @@
+package notify
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// Embed colours for Discord, by outcome
+const (
+	colorGood     = 0x2EB67D
+	colorWarning  = 0xECB22E
+	colorCritical = 0xE01E5A
+)
+
+// chatField is a labelled value shown in a chat message
+type chatField struct {
+	Name  string
+	Value string
+}
+
+// chatMessage is a notification independent of the chat service it is rendered for
+type chatMessage struct {
+	Title     string
+	Text      string
+	Fields    []chatField
+	Color     int
+	URL       string // Dashboard page for the run
+	Timestamp time.Time
+}
+
+// chatMessage builds the message for a benchmark completion or a raised alert.
+// It reports false for events that are not sent to chat channels.
+func (s *Service) chatMessage(event events.Event) (chatMessage, bool) {
+	switch data := event.Data.(type) {
+	case models.BenchmarkResults:
+		if event.Type != events.BenchmarkCompleted {
+			return chatMessage{}, false
+		}
+		return benchmarkMessage(data, previousQualityScore(data), s.runURL(data.RunID)), true
+	case models.Alert:
+		return alertMessage(data, s.runURL(data.RunID)), true
+	default:
+		return chatMessage{}, false
+	}
+}
+
+// runURL links to the dashboard page for a run
+func (s *Service) runURL(runID string) string {
+	if s.dashboardURL == "" || runID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/run/%s", s.dashboardURL, runID)
+}
+
+// previousQualityScore returns the quality score of the persona's benchmark before results, or nil if there is none
+func previousQualityScore(results models.BenchmarkResults) *float64 {
+	all, err := storage.ListBenchmarkResults()
+	if err != nil {
+		log.Printf("Error listing benchmarks for score delta: %v", err)
+		return nil
+	}
+
+	var previous *models.BenchmarkResults
+	for i, candidate := range all {
+		if candidate.PersonaName != results.PersonaName || candidate.BenchmarkID == results.BenchmarkID ||
+			candidate.FailureReason != "" || !candidate.Timestamp.Before(results.Timestamp) {
+			continue
+		}
+		if previous == nil || candidate.Timestamp.After(previous.Timestamp) {
+			previous = &all[i]
+		}
+	}
+	if previous == nil {
+		return nil
+	}
+	return &previous.QualityScore
+}
+
+// benchmarkMessage describes a completed benchmark, with the change since the persona's previous benchmark
+func benchmarkMessage(results models.BenchmarkResults, previousScore *float64, url string) chatMessage {
+	delta := "n/a"
+	color := colorGood
+	if previousScore != nil {
+		change := results.QualityScore - *previousScore
+		delta = fmt.Sprintf("%+.1f", change)
+		if change < 0 {
+			color = colorWarning
+		}
+	}
+
+	return chatMessage{
+		Title: fmt.Sprintf("Benchmark completed for %s", results.PersonaName),
+		Text:  fmt.Sprintf("Run %s was evaluated with status %s.", results.RunID, results.Status),
+		Fields: []chatField{
+			{Name: "Persona", Value: results.PersonaName},
+			{Name: "Quality score", Value: fmt.Sprintf("%.1f", results.QualityScore)},
+			{Name: "Change", Value: delta},
+			{Name: "Relevance accuracy", Value: fmt.Sprintf("%.1f%%", results.RelevanceAccuracy*100)}, // Stored as a fraction
+			{Name: "Items", Value: fmt.Sprintf("%d", results.TotalItems)},
+		},
+		Color:     color,
+		URL:       url,
+		Timestamp: results.Timestamp,
+	}
+}
+
+// alertMessage describes a raised regression alert
+func alertMessage(alert models.Alert, url string) chatMessage {
+	color := colorWarning
+	if alert.Severity == models.AlertSeverityCritical {
+		color = colorCritical
+	}
+
+	return chatMessage{
+		Title: fmt.Sprintf("%s alert for %s: %s", alert.Severity, alert.PersonaName, alert.Rule),
+		Text:  alert.Message,
+		Fields: []chatField{
+			{Name: "Persona", Value: alert.PersonaName},
+			{Name: "Severity", Value: alert.Severity},
+			{Name: "Value", Value: fmt.Sprintf("%.1f", alert.Value)},
+			{Name: "Threshold", Value: fmt.Sprintf("%.1f", alert.Threshold)},
+		},
+		Color:     color,
+		URL:       url,
+		Timestamp: alert.CreatedAt,
+	}
+}
+
+// slackPayload renders a message as Slack Block Kit blocks for an incoming webhook
+func slackPayload(message chatMessage) map[string]interface{} {
+	fields := make([]map[string]interface{}, 0, len(message.Fields))
+	for _, field := range message.Fields {
+		fields = append(fields, map[string]interface{}{
+			"type": "mrkdwn",
+			"text": fmt.Sprintf("*%s*\n%s", field.Name, field.Value),
+		})
+	}
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": message.Title},
+		},
+		{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": message.Text},
+		},
+		{
+			"type":   "section",
+			"fields": fields,
+		},
+	}
+	if message.URL != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []map[string]interface{}{{
+				"type": "button",
+				"text": map[string]interface{}{"type": "plain_text", "text": "View run"},
+				"url":  message.URL,
+			}},
+		})
+	}
+
+	// text is the fallback shown in notifications
+	return map[string]interface{}{
+		"text":   message.Title,
+		"blocks": blocks,
+	}
+}
+
+// discordPayload renders a message as a Discord embed
+func discordPayload(message chatMessage) map[string]interface{} {
+	fields := make([]map[string]interface{}, 0, len(message.Fields))
+	for _, field := range message.Fields {
+		fields = append(fields, map[string]interface{}{
+			"name":   field.Name,
+			"value":  field.Value,
+			"inline": true,
+		})
+	}
+
+	embed := map[string]interface{}{
+		"title":       message.Title,
+		"description": message.Text,
+		"color":       message.Color,
+		"fields":      fields,
+	}
+	if message.URL != "" {
+		embed["url"] = message.URL
+	}
+	if !message.Timestamp.IsZero() {
+		embed["timestamp"] = message.Timestamp.Format(time.RFC3339)
+	}
+
+	return map[string]interface{}{
+		"embeds": []map[string]interface{}{embed},
+	}
+}
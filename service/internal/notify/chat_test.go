@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// chatStandIn is a local stand-in for a Slack or Discord incoming webhook that records the bodies it receives
+func chatStandIn(t *testing.T) (*httptest.Server, <-chan []byte) {
+	t.Helper()
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(HeaderSignature) != "" {
+			t.Error("chat deliveries should not be signed")
+		}
+		bodies <- body
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, bodies
+}
+
+func testService(server *httptest.Server) *Service {
+	return &Service{client: server.Client(), retryConfig: fastRetry, dashboardURL: "http://dashboard.test"}
+}
+
+func TestSlackDeliveryOfAlert(t *testing.T) {
+	server, bodies := chatStandIn(t)
+	s := testService(server)
+
+	alert := models.Alert{
+		Rule:        "quality_drop",
+		Severity:    models.AlertSeverityCritical,
+		PersonaName: "LocalLLaMA",
+		RunID:       "run-1",
+		Message:     "Quality score dropped",
+		Value:       22,
+		Threshold:   10,
+		CreatedAt:   time.Now(),
+	}
+	webhook := models.Webhook{ID: "w", Type: models.WebhookTypeSlack, URL: server.URL, Enabled: true}
+	s.deliver(webhook, events.Event{Type: events.AlertRaised, Data: alert})
+
+	var payload struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type     string `json:"type"`
+			Elements []struct {
+				URL string `json:"url"`
+			} `json:"elements"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(<-bodies, &payload); err != nil {
+		t.Fatalf("invalid Slack payload: %v", err)
+	}
+	if !strings.Contains(payload.Text, "LocalLLaMA") {
+		t.Errorf("fallback text %q does not name the persona", payload.Text)
+	}
+	last := payload.Blocks[len(payload.Blocks)-1]
+	if last.Type != "actions" || last.Elements[0].URL != "http://dashboard.test/run/run-1" {
+		t.Errorf("last block = %+v, want a button linking to the run page", last)
+	}
+}
+
+func TestDiscordDeliveryOfBenchmark(t *testing.T) {
+	server, bodies := chatStandIn(t)
+	s := testService(server)
+
+	results := models.BenchmarkResults{
+		BenchmarkID:       "b",
+		RunID:             "run-2",
+		PersonaName:       "LocalLLaMA",
+		QualityScore:      72.5,
+		RelevanceAccuracy: 0.9,
+		Status:            models.BenchmarkStatusCompleted,
+		Timestamp:         time.Now(),
+	}
+	webhook := models.Webhook{ID: "w", Type: models.WebhookTypeDiscord, URL: server.URL, Enabled: true}
+	s.deliver(webhook, events.Event{Type: events.BenchmarkCompleted, Data: results})
+
+	var payload struct {
+		Embeds []struct {
+			Title  string `json:"title"`
+			URL    string `json:"url"`
+			Fields []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"fields"`
+		} `json:"embeds"`
+	}
+	if err := json.Unmarshal(<-bodies, &payload); err != nil {
+		t.Fatalf("invalid Discord payload: %v", err)
+	}
+	embed := payload.Embeds[0]
+	if embed.URL != "http://dashboard.test/run/run-2" {
+		t.Errorf("embed URL = %q, want the run page", embed.URL)
+	}
+	fields := make(map[string]string)
+	for _, f := range embed.Fields {
+		fields[f.Name] = f.Value
+	}
+	if fields["Quality score"] != "72.5" || fields["Persona"] != "LocalLLaMA" {
+		t.Errorf("fields = %v, want persona and quality score", fields)
+	}
+	if fields["Relevance accuracy"] != "90.0%" {
+		t.Errorf("relevance accuracy = %q, want 90.0%%", fields["Relevance accuracy"])
+	}
+}
+
+func TestChatChannelsSkipUnformattedEvents(t *testing.T) {
+	s := &Service{}
+	webhook := models.Webhook{Type: models.WebhookTypeSlack}
+	for _, event := range []events.Event{
+		{Type: events.RunIngested, Data: models.RunMetadata{ID: "run"}},
+		{Type: events.BenchmarkFailed, Data: models.BenchmarkResults{FailureReason: "boom"}},
+	} {
+		if _, _, ok, err := s.render(webhook, event, "d"); ok || err != nil {
+			t.Errorf("render(%s) = ok %v, err %v, want it skipped", event.Type, ok, err)
+		}
+	}
+}
+
+func TestBenchmarkMessageDelta(t *testing.T) {
+	previous := 80.0
+	message := benchmarkMessage(models.BenchmarkResults{QualityScore: 75}, &previous, "")
+	for _, field := range message.Fields {
+		if field.Name == "Change" && field.Value != "-5.0" {
+			t.Errorf("Change = %q, want -5.0", field.Value)
+		}
+	}
+	if message.Color != colorWarning {
+		t.Errorf("Color = %#x, want the warning colour for a drop", message.Color)
+	}
+}
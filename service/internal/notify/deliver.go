@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/http/retry"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// Headers sent with every webhook delivery
+const (
+	HeaderEvent     = "X-ANAS-Event"
+	HeaderDelivery  = "X-ANAS-Delivery"
+	HeaderSignature = "X-ANAS-Signature" // "sha256=" followed by the hex HMAC-SHA256 of the body
+)
+
+// deliveryRetryConfig spreads retries over several minutes so short receiver outages are survived
+var deliveryRetryConfig = retry.RetryConfig{
+	MaxRetries:      5,
+	InitialBackoff:  2 * time.Second,
+	MaxBackoff:      2 * time.Minute,
+	BackoffFactor:   2.0,
+	MaxTotalTimeout: 10 * time.Minute,
+}
+
+// deliveryTimeout bounds a single delivery attempt
+const deliveryTimeout = 10 * time.Second
+
+// errPermanent marks delivery failures that retrying will not fix, such as a 4xx response
+var errPermanent = errors.New("permanent delivery failure")
+
+// Sign returns the signature of body for the X-ANAS-Signature header.
+// Receivers verify a delivery by computing the same value with the shared secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// post sends body to url with retries, calling onAttempt after every attempt
+func post(ctx context.Context, client *http.Client, config retry.RetryConfig, url string, headers map[string]string, body []byte, onAttempt func(models.DeliveryAttempt)) error {
+	attempt := 0
+	_, err := retry.RetryWithBackoff(ctx, config,
+		func(ctx context.Context) (*http.Response, error) {
+			attempt++
+			record := models.DeliveryAttempt{Attempt: attempt, Timestamp: time.Now()}
+			resp, err := postOnce(ctx, client, url, headers, body)
+			record.DurationMs = time.Since(record.Timestamp).Milliseconds()
+			if resp != nil {
+				record.StatusCode = resp.StatusCode
+			}
+			if err != nil {
+				record.Error = err.Error()
+			}
+			onAttempt(record)
+			return resp, err
+		},
+		func(err error) bool {
+			return !errors.Is(err, errPermanent)
+		},
+	)
+	return err
+}
+
+// postOnce makes a single delivery attempt. Rate limiting and server errors are retryable, other
+// non-2xx responses are permanent. The response body is drained and closed before returning.
+func postOnce(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errPermanent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return resp, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return resp, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	default:
+		return resp, fmt.Errorf("%w: receiver responded with status %d", errPermanent, resp.StatusCode)
+	}
+}
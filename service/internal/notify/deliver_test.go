@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/http/retry"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+// fastRetry keeps retry tests quick
+var fastRetry = retry.RetryConfig{
+	MaxRetries:     3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+	BackoffFactor:  1,
+}
+
+func TestPostSignsAndRetries(t *testing.T) {
+	body := []byte(`{"type":"benchmark.completed"}`)
+	secret := "s3cret"
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := io.ReadAll(r.Body)
+		if got, want := r.Header.Get(HeaderSignature), Sign(secret, received); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var attempts []models.DeliveryAttempt
+	headers := map[string]string{HeaderSignature: Sign(secret, body)}
+	err := post(context.Background(), server.Client(), fastRetry, server.URL, headers, body, func(a models.DeliveryAttempt) {
+		attempts = append(attempts, a)
+	})
+	if err != nil {
+		t.Fatalf("post() error = %v", err)
+	}
+	if len(attempts) != 3 {
+		t.Fatalf("recorded %d attempts, want 3", len(attempts))
+	}
+	if attempts[0].StatusCode != http.StatusBadGateway || attempts[0].Error == "" {
+		t.Errorf("first attempt = %+v, want a recorded 502 failure", attempts[0])
+	}
+	if attempts[2].StatusCode != http.StatusNoContent || attempts[2].Error != "" {
+		t.Errorf("last attempt = %+v, want a 204 success", attempts[2])
+	}
+}
+
+func TestPostDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := post(context.Background(), server.Client(), fastRetry, server.URL, nil, []byte(`{}`), func(models.DeliveryAttempt) {})
+	if err == nil {
+		t.Fatal("post() succeeded, want an error for a 400 response")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("receiver called %d times, want 1", calls.Load())
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Reference value from: printf '{}' | openssl dgst -sha256 -hmac key
+	want := "sha256=a777724d943eb48dc69bca8a4a6d57a04db3f9ec7e1de4e581e860265bdf3032"
+	if got := Sign("key", []byte("{}")); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
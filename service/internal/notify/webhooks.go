@@ -0,0 +1,355 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
+	"github.com/bakkerme/ai-news-auditability-service/internal/http/retry"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidWebhook is returned for webhook requests that fail validation
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// Payload is the JSON body delivered to webhooks
+type Payload struct {
+	ID        string      `json:"id"` // Delivery ID, also sent in X-ANAS-Delivery
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// benchmarkSummary is the webhook data for benchmark events, leaving out the per-item evaluations
+type benchmarkSummary struct {
+	BenchmarkID       string    `json:"benchmarkId"`
+	RunID             string    `json:"runId"`
+	PersonaName       string    `json:"personaName,omitempty"`
+	Timestamp         time.Time `json:"timestamp"`
+	Status            string    `json:"status"`
+	QualityScore      float64   `json:"qualityScore"`
+	RelevanceAccuracy float64   `json:"relevanceAccuracy"`
+	Coverage          float64   `json:"coverage"`
+	TotalItems        int       `json:"totalItems"`
+	ErroredItems      int       `json:"erroredItems,omitempty"`
+	FailureReason     string    `json:"failureReason,omitempty"`
+}
+
+// Service delivers service events to the configured webhooks
+type Service struct {
+	client       *http.Client
+	retryConfig  retry.RetryConfig
+	dashboardURL string     // Base URL for links in chat messages
+	mu           sync.Mutex // Serialises webhook updates
+}
+
+// NewService creates a webhook service that delivers every event type published on bus
+func NewService(bus *events.Bus, dashboardURL string) *Service {
+	s := &Service{
+		client:       &http.Client{},
+		retryConfig:  deliveryRetryConfig,
+		dashboardURL: strings.TrimRight(dashboardURL, "/"),
+	}
+	if bus != nil {
+		for _, eventType := range events.Types {
+			bus.Subscribe(eventType, s.handleEvent)
+		}
+	}
+	return s
+}
+
+// handleEvent delivers an event to every enabled webhook subscribed to its type
+func (s *Service) handleEvent(event events.Event) {
+	webhooks, err := storage.ListWebhooks()
+	if err != nil {
+		log.Printf("Error listing webhooks for %s event: %v", event.Type, err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, webhook := range webhooks {
+		if !webhook.Enabled || !subscribed(webhook, event.Type) {
+			continue
+		}
+		wg.Add(1)
+		go func(webhook models.Webhook) {
+			defer wg.Done()
+			s.deliver(webhook, event)
+		}(webhook)
+	}
+	wg.Wait()
+}
+
+// subscribed reports whether a webhook receives an event type
+func subscribed(webhook models.Webhook, eventType string) bool {
+	return len(webhook.Events) == 0 || slices.Contains(webhook.Events, eventType)
+}
+
+// payloadData converts event data into the form sent to receivers
+func payloadData(data interface{}) interface{} {
+	results, ok := data.(models.BenchmarkResults)
+	if !ok {
+		return data
+	}
+	return benchmarkSummary{
+		BenchmarkID:       results.BenchmarkID,
+		RunID:             results.RunID,
+		PersonaName:       results.PersonaName,
+		Timestamp:         results.Timestamp,
+		Status:            results.Status,
+		QualityScore:      results.QualityScore,
+		RelevanceAccuracy: results.RelevanceAccuracy,
+		Coverage:          results.Coverage,
+		TotalItems:        results.TotalItems,
+		ErroredItems:      len(results.ErroredItems),
+		FailureReason:     results.FailureReason,
+	}
+}
+
+// render builds the request body and headers for delivering an event to a webhook.
+// It reports false when the webhook's type has no format for the event.
+func (s *Service) render(webhook models.Webhook, event events.Event, deliveryID string) ([]byte, map[string]string, bool, error) {
+	switch webhook.Type {
+	case models.WebhookTypeSlack, models.WebhookTypeDiscord:
+		message, ok := s.chatMessage(event)
+		if !ok {
+			return nil, nil, false, nil
+		}
+		var payload interface{} = slackPayload(message)
+		if webhook.Type == models.WebhookTypeDiscord {
+			payload = discordPayload(message)
+		}
+		body, err := json.Marshal(payload)
+		return body, nil, err == nil, err
+	default:
+		body, err := json.Marshal(Payload{
+			ID:        deliveryID,
+			Type:      event.Type,
+			Timestamp: event.Timestamp,
+			Data:      payloadData(event.Data),
+		})
+		if err != nil {
+			return nil, nil, false, err
+		}
+		headers := map[string]string{
+			HeaderEvent:     event.Type,
+			HeaderDelivery:  deliveryID,
+			HeaderSignature: Sign(webhook.Secret, body),
+		}
+		return body, headers, true, nil
+	}
+}
+
+// deliver sends an event to a webhook, recording each attempt in the delivery log
+func (s *Service) deliver(webhook models.Webhook, event events.Event) {
+	delivery := models.WebhookDelivery{
+		ID:        uuid.NewString(),
+		WebhookID: webhook.ID,
+		EventType: event.Type,
+		Status:    models.DeliveryStatusPending,
+		Attempts:  []models.DeliveryAttempt{},
+		CreatedAt: time.Now(),
+	}
+
+	body, headers, ok, err := s.render(webhook, event, delivery.ID)
+	if err != nil {
+		log.Printf("Error rendering %s event for webhook %s: %v", event.Type, webhook.ID, err)
+		return
+	}
+	if !ok {
+		// Chat channels only receive the events they have a message format for
+		return
+	}
+
+	save := func() {
+		if err := storage.SaveWebhookDelivery(delivery); err != nil {
+			log.Printf("Error saving delivery %s for webhook %s: %v", delivery.ID, webhook.ID, err)
+		}
+	}
+	save()
+
+	err = post(context.Background(), s.client, s.retryConfig, webhook.URL, headers, body, func(attempt models.DeliveryAttempt) {
+		delivery.Attempts = append(delivery.Attempts, attempt)
+		save()
+	})
+
+	now := time.Now()
+	delivery.CompletedAt = &now
+	delivery.Status = models.DeliveryStatusDelivered
+	if err != nil {
+		log.Printf("Failed to deliver %s event to webhook %s after %d attempt(s): %v", event.Type, webhook.ID, len(delivery.Attempts), err)
+		delivery.Status = models.DeliveryStatusFailed
+	}
+	save()
+}
+
+// List returns all webhooks, without their secrets
+func (s *Service) List() ([]models.Webhook, error) {
+	webhooks, err := storage.ListWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	return webhooks, nil
+}
+
+// Get returns a webhook by ID, without its secret
+func (s *Service) Get(webhookID string) (*models.Webhook, error) {
+	webhook, err := storage.GetWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// Create validates and stores a new webhook. The returned webhook includes its secret,
+// which is not returned again.
+func (s *Service) Create(request models.WebhookRequest) (*models.Webhook, error) {
+	now := time.Now()
+	webhook := models.Webhook{
+		ID:        uuid.NewString(),
+		Name:      request.Name,
+		Type:      request.Type,
+		URL:       request.URL,
+		Events:    request.Events,
+		Secret:    request.Secret,
+		Enabled:   request.Enabled == nil || *request.Enabled,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if webhook.Type == "" {
+		webhook.Type = models.WebhookTypeGeneric
+	}
+	// Chat services authenticate by their secret URL, so only generic webhooks are signed
+	if webhook.Secret == "" && webhook.Type == models.WebhookTypeGeneric {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+	if err := validateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := storage.SaveWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Update changes the fields set in request and stores the webhook
+func (s *Service) Update(webhookID string, request models.WebhookRequest) (*models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook, err := storage.GetWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Name != "" {
+		webhook.Name = request.Name
+	}
+	if request.Type != "" {
+		webhook.Type = request.Type
+	}
+	if request.URL != "" {
+		webhook.URL = request.URL
+	}
+	if request.Events != nil {
+		webhook.Events = request.Events
+	}
+	if request.Secret != "" {
+		webhook.Secret = request.Secret
+	}
+	if request.Enabled != nil {
+		webhook.Enabled = *request.Enabled
+	}
+	webhook.UpdatedAt = time.Now()
+	if err := validateWebhook(*webhook); err != nil {
+		return nil, err
+	}
+
+	if err := storage.SaveWebhook(*webhook); err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// Delete removes a webhook
+func (s *Service) Delete(webhookID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return storage.DeleteWebhook(webhookID)
+}
+
+// Deliveries returns the delivery log of a webhook, newest first
+func (s *Service) Deliveries(webhookID string) ([]models.WebhookDelivery, error) {
+	if _, err := storage.GetWebhook(webhookID); err != nil {
+		return nil, err
+	}
+	deliveries, err := storage.ListWebhookDeliveries(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	return deliveries, nil
+}
+
+// validateWebhook checks the type, secret, URL and event types of a webhook
+func validateWebhook(webhook models.Webhook) error {
+	switch webhook.Type {
+	case models.WebhookTypeGeneric, models.WebhookTypeSlack, models.WebhookTypeDiscord:
+	default:
+		return fmt.Errorf("%w: type must be one of %s, %s, %s", ErrInvalidWebhook,
+			models.WebhookTypeGeneric, models.WebhookTypeSlack, models.WebhookTypeDiscord)
+	}
+	// Deliveries to generic webhooks are signed, which an empty key would make meaningless
+	if webhook.Type == models.WebhookTypeGeneric && webhook.Secret == "" {
+		return fmt.Errorf("%w: a secret is required for %s webhooks", ErrInvalidWebhook, models.WebhookTypeGeneric)
+	}
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	for _, eventType := range webhook.Events {
+		if !slices.Contains(events.Types, eventType) {
+			return fmt.Errorf("%w: unknown event type %q, expected one of %v", ErrInvalidWebhook, eventType, events.Types)
+		}
+	}
+	return nil
+}
+
+// generateSecret returns a random hex-encoded signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
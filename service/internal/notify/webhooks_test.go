@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+)
+
+// initTestDB opens a fresh database for the duration of a test
+func initTestDB(t *testing.T) {
+	t.Helper()
+	if err := storage.InitDB(t.TempDir(), 0); err != nil {
+		t.Fatalf("InitDB() error = %v", err)
+	}
+	t.Cleanup(storage.CloseDB)
+}
+
+func TestValidateWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook models.Webhook
+		wantErr bool
+	}{
+		{name: "generic", webhook: models.Webhook{Type: models.WebhookTypeGeneric, URL: "https://example.com/hook", Secret: "s"}},
+		{name: "slack without secret", webhook: models.Webhook{Type: models.WebhookTypeSlack, URL: "https://hooks.slack.com/x"}},
+		{name: "known events", webhook: models.Webhook{Type: models.WebhookTypeDiscord, URL: "https://discord.com/x", Events: []string{events.AlertRaised}}},
+		{name: "generic without secret", webhook: models.Webhook{Type: models.WebhookTypeGeneric, URL: "https://example.com/hook"}, wantErr: true},
+		{name: "unknown type", webhook: models.Webhook{Type: "teams", URL: "https://example.com/hook"}, wantErr: true},
+		{name: "relative url", webhook: models.Webhook{Type: models.WebhookTypeSlack, URL: "/hook"}, wantErr: true},
+		{name: "unsupported scheme", webhook: models.Webhook{Type: models.WebhookTypeSlack, URL: "ftp://example.com/hook"}, wantErr: true},
+		{name: "unknown event", webhook: models.Webhook{Type: models.WebhookTypeSlack, URL: "https://example.com/hook", Events: []string{"run.deleted"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWebhook(tt.webhook)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidWebhook) {
+				t.Errorf("error %v does not wrap ErrInvalidWebhook", err)
+			}
+		})
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	all := models.Webhook{}
+	alerts := models.Webhook{Events: []string{events.AlertRaised}}
+
+	if !subscribed(all, events.BenchmarkCompleted) || !subscribed(all, events.AlertRaised) {
+		t.Error("a webhook without events should receive every event")
+	}
+	if !subscribed(alerts, events.AlertRaised) || subscribed(alerts, events.BenchmarkCompleted) {
+		t.Error("a webhook with events should only receive those events")
+	}
+}
+
+func TestCreateAndUpdate(t *testing.T) {
+	initTestDB(t)
+	s := &Service{}
+
+	generic, err := s.Create(models.WebhookRequest{Name: "ci", URL: "https://example.com/hook"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if generic.Type != models.WebhookTypeGeneric || generic.Secret == "" || !generic.Enabled {
+		t.Errorf("created webhook = %+v, want an enabled generic webhook with a generated secret", generic)
+	}
+
+	slack, err := s.Create(models.WebhookRequest{Type: models.WebhookTypeSlack, URL: "https://hooks.slack.com/x"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if slack.Secret != "" {
+		t.Error("chat webhooks should not be given a signing secret")
+	}
+
+	if _, err := s.Create(models.WebhookRequest{URL: "not a url"}); !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("Create() with an invalid URL error = %v, want ErrInvalidWebhook", err)
+	}
+
+	// Becoming generic needs a secret to sign with
+	if _, err := s.Update(slack.ID, models.WebhookRequest{Type: models.WebhookTypeGeneric}); !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("Update() to generic without a secret error = %v, want ErrInvalidWebhook", err)
+	}
+	updated, err := s.Update(slack.ID, models.WebhookRequest{Type: models.WebhookTypeGeneric, Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Type != models.WebhookTypeGeneric || updated.Secret != "" {
+		t.Errorf("updated webhook = %+v, want a generic webhook with its secret hidden", updated)
+	}
+	stored, err := storage.GetWebhook(slack.ID)
+	if err != nil || stored.Secret != "s3cret" {
+		t.Errorf("stored webhook = %+v, %v, want the new secret kept", stored, err)
+	}
+
+	disabled := false
+	if updated, err := s.Update(generic.ID, models.WebhookRequest{Enabled: &disabled}); err != nil || updated.Enabled || updated.URL != generic.URL {
+		t.Errorf("Update() = %+v, %v, want only enabled changed", updated, err)
+	}
+
+	webhooks, err := s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(webhooks) != 2 {
+		t.Fatalf("List() = %+v, want two webhooks", webhooks)
+	}
+	for _, webhook := range webhooks {
+		if webhook.Secret != "" {
+			t.Errorf("List() returned the secret of %s", webhook.ID)
+		}
+	}
+	got, err := s.Get(generic.ID)
+	if err != nil || got.Secret != "" {
+		t.Errorf("Get() = %+v, %v, want the secret hidden", got, err)
+	}
+}
+
+func TestDeliverRecordsDelivery(t *testing.T) {
+	initTestDB(t)
+
+	secret := "s3cret"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if got, want := r.Header.Get(HeaderSignature), Sign(secret, body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		var payload Payload
+		if err := json.Unmarshal(body, &payload); err != nil || payload.ID != r.Header.Get(HeaderDelivery) {
+			t.Errorf("payload = %+v, %v, want the delivery ID of the header", payload, err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	s := testService(server)
+
+	webhook := models.Webhook{ID: "w", Type: models.WebhookTypeGeneric, URL: server.URL, Secret: secret, Enabled: true}
+	if err := storage.SaveWebhook(webhook); err != nil {
+		t.Fatalf("SaveWebhook() error = %v", err)
+	}
+	s.deliver(webhook, events.Event{Type: events.BenchmarkCompleted, Timestamp: time.Now(), Data: models.BenchmarkResults{BenchmarkID: "b"}})
+
+	deliveries, err := s.Deliveries("w")
+	if err != nil {
+		t.Fatalf("Deliveries() error = %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("Deliveries() = %+v, want one delivery", deliveries)
+	}
+	delivery := deliveries[0]
+	if delivery.Status != models.DeliveryStatusDelivered || delivery.EventType != events.BenchmarkCompleted || delivery.CompletedAt == nil {
+		t.Errorf("delivery = %+v, want a completed benchmark.completed delivery", delivery)
+	}
+	if len(delivery.Attempts) != 1 || delivery.Attempts[0].StatusCode != http.StatusNoContent {
+		t.Errorf("attempts = %+v, want one 204 attempt", delivery.Attempts)
+	}
+}
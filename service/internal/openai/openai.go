@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/bakkerme/ai-news-auditability-service/internal/customerrors"
 	"github.com/bakkerme/ai-news-auditability-service/internal/http/retry"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
@@ -22,6 +21,21 @@ type SchemaParameters struct {
 	Description string
 }
 
+// Usage reports the tokens consumed by a request
+type Usage struct {
+	PromptTokens     int64
+	CompletionTokens int64
+	TotalTokens      int64
+}
+
+// CompletionResult is a chat completion response together with what it cost to produce
+type CompletionResult struct {
+	Value   string
+	Usage   Usage
+	Latency time.Duration // Including any retries
+	Err     error
+}
+
 // OpenAIClient defines the interface for interacting with an OpenAI-compatible API
 type OpenAIClient interface {
 	// ChatCompletion performs a general-purpose chat completion request
@@ -31,7 +45,7 @@ type OpenAIClient interface {
 	// schemaParams: Optional schema parameters for response formatting (can be nil)
 	// temperature: The temperature to use for the API call
 	// maxTokens: Optional max tokens parameter to limit the response length (0 means no limit)
-	// returns: Channel that will receive the response or error
+	// returns: Channel that will receive the response and its token usage, or an error
 	ChatCompletion(
 		systemPrompt string,
 		userPrompts []string,
@@ -39,9 +53,13 @@ type OpenAIClient interface {
 		schemaParams *SchemaParameters,
 		temperature float64,
 		maxTokens int,
-		results chan customerrors.ErrorString,
+		results chan CompletionResult,
 	)
 
+	// Embeddings returns an embedding vector for each input, in input order, and the tokens used
+	// inputs: The texts to embed
+	Embeddings(inputs []string) ([][]float64, Usage, error)
+
 	// SetRetryConfig updates the retry behavior configuration
 	SetRetryConfig(config retry.RetryConfig)
 
@@ -126,7 +144,7 @@ func (c *Client) ChatCompletion(
 	schemaParams *SchemaParameters,
 	temperature float64,
 	maxTokens int,
-	results chan customerrors.ErrorString,
+	results chan CompletionResult,
 ) {
 	// Prepare messages array
 	messages := []openai.ChatCompletionMessageParamUnion{
@@ -205,7 +223,9 @@ func (c *Client) ChatCompletion(
 		return c.client.Chat.Completions.New(ctx, params)
 	}
 
+	start := time.Now()
 	resp, err := retry.RetryWithBackoff(context.Background(), c.retry, ChatCompletionFn, shouldRetry)
+	latency := time.Since(start)
 
 	if err != nil {
 		var errMsg string
@@ -215,17 +235,26 @@ func (c *Client) ChatCompletion(
 			errMsg = fmt.Sprintf("error during API call: %v", err)
 		}
 
-		results <- customerrors.ErrorString{
-			Value: "",
-			Err:   errors.New(errMsg),
+		results <- CompletionResult{
+			Value:   "",
+			Latency: latency,
+			Err:     errors.New(errMsg),
 		}
 		return
 	}
 
+	usage := Usage{
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		TotalTokens:      resp.Usage.TotalTokens,
+	}
+
 	if len(resp.Choices) == 0 {
-		results <- customerrors.ErrorString{
-			Value: "",
-			Err:   fmt.Errorf("empty response from llm"),
+		results <- CompletionResult{
+			Value:   "",
+			Usage:   usage,
+			Latency: latency,
+			Err:     fmt.Errorf("empty response from llm"),
 		}
 		return
 	}
@@ -250,10 +279,62 @@ func (c *Client) ChatCompletion(
 		requestWordCount,
 	)
 
-	results <- customerrors.ErrorString{
-		Value: resp.Choices[0].Message.Content,
-		Err:   nil,
+	results <- CompletionResult{
+		Value:   resp.Choices[0].Message.Content,
+		Usage:   usage,
+		Latency: latency,
+		Err:     nil,
+	}
+}
+
+// Embeddings requests embedding vectors for the given inputs using the client's model
+func (c *Client) Embeddings(inputs []string) ([][]float64, Usage, error) {
+	if len(inputs) == 0 {
+		return [][]float64{}, Usage{}, nil
+	}
+
+	params := openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModel(c.model),
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: inputs},
+	}
+
+	shouldRetry := func(err error) bool {
+		return isModelLoadingError(err)
+	}
+
+	embeddingFn := func(ctx context.Context) (*openai.CreateEmbeddingResponse, error) {
+		return c.client.Embeddings.New(ctx, params)
+	}
+
+	resp, err := retry.RetryWithBackoff(context.Background(), c.retry, embeddingFn, shouldRetry)
+	if err != nil {
+		if isModelLoadingError(err) {
+			return nil, Usage{}, fmt.Errorf("embedding model failed to load after retries: %w", err)
+		}
+		return nil, Usage{}, fmt.Errorf("error during embeddings API call: %w", err)
+	}
+
+	usage := Usage{
+		PromptTokens: resp.Usage.PromptTokens,
+		TotalTokens:  resp.Usage.TotalTokens,
+	}
+
+	if len(resp.Data) != len(inputs) {
+		return nil, usage, fmt.Errorf("expected %d embeddings, got %d", len(inputs), len(resp.Data))
+	}
+
+	// The API reports each embedding's position, which may not match response order
+	embeddings := make([][]float64, len(inputs))
+	for _, data := range resp.Data {
+		if data.Index < 0 || int(data.Index) >= len(inputs) {
+			return nil, usage, fmt.Errorf("embedding index %d out of range", data.Index)
+		}
+		embeddings[data.Index] = data.Embedding
 	}
+
+	log.Printf("Embedding Token Usage - Model: %s, Inputs: %d, Input Tokens: %d", c.model, len(inputs), resp.Usage.PromptTokens)
+
+	return embeddings, usage, nil
 }
 
 // PreprocessYAML extracts YAML content from the API response
@@ -1,6 +1,11 @@
 package openai
 
-import "testing"
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
 
 func TestPreprocessJSON(t *testing.T) {
 	client := &Client{}
@@ -209,3 +214,83 @@ func TestNewWithSafeTimeouts(t *testing.T) {
 		t.Errorf("expected MaxRetries to be %d, got %d", SafeOpenAIRetryConfig.MaxRetries, client.retry.MaxRetries)
 	}
 }
+
+func TestEmbeddings(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected [][]float64
+		wantErr  bool
+	}{
+		{
+			name:     "results reordered by index",
+			response: `{"object": "list", "model": "embed", "data": [{"object": "embedding", "index": 1, "embedding": [0, 1]}, {"object": "embedding", "index": 0, "embedding": [1, 0]}], "usage": {"prompt_tokens": 7, "total_tokens": 7}}`,
+			expected: [][]float64{{1, 0}, {0, 1}},
+		},
+		{
+			name:     "fewer embeddings than inputs",
+			response: `{"object": "list", "model": "embed", "data": [{"object": "embedding", "index": 0, "embedding": [1, 0]}], "usage": {"prompt_tokens": 7, "total_tokens": 7}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "index out of range",
+			response: `{"object": "list", "model": "embed", "data": [{"object": "embedding", "index": 0, "embedding": [1, 0]}, {"object": "embedding", "index": 2, "embedding": [0, 1]}], "usage": {"prompt_tokens": 7, "total_tokens": 7}}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request struct {
+				Model string   `json:"model"`
+				Input []string `json:"input"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/embeddings" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := New(server.URL, "test-key", "embed")
+			embeddings, usage, err := client.Embeddings([]string{"first", "second"})
+
+			if request.Model != "embed" || len(request.Input) != 2 || request.Input[0] != "first" {
+				t.Errorf("unexpected request %+v", request)
+			}
+			if usage.PromptTokens != 7 {
+				t.Errorf("expected 7 prompt tokens, got %d", usage.PromptTokens)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got embeddings %v", embeddings)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(embeddings) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, embeddings)
+			}
+			for i := range tt.expected {
+				if len(embeddings[i]) != len(tt.expected[i]) || embeddings[i][0] != tt.expected[i][0] || embeddings[i][1] != tt.expected[i][1] {
+					t.Errorf("expected embedding %d to be %v, got %v", i, tt.expected[i], embeddings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddingsNoInputs(t *testing.T) {
+	client := New("http://localhost:0", "test-key", "embed")
+	embeddings, _, err := client.Embeddings(nil)
+	if err != nil || len(embeddings) != 0 {
+		t.Errorf("expected no embeddings and no error, got %v, %v", embeddings, err)
+	}
+}
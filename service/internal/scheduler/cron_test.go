@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Wednesday 15 January 2025, 10:30
+	from := time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2025, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2025, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{expr: "0 2 * * *", want: time.Date(2025, time.January, 16, 2, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{expr: "30 10 * * *", want: time.Date(2025, time.January, 16, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 9 * * 1-5", want: time.Date(2025, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 * *", want: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 20th or any Monday, whichever is first
+		{expr: "0 0 20 * 1", want: time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 17 * 1", want: time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		// A field starting with * is unrestricted even with a step, so both day fields must match
+		{expr: "0 0 */2 * 1", want: time.Date(2025, time.January, 27, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 20 * */2", want: time.Date(2025, time.February, 20, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			if got := cron.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// checkInterval is how often schedules are checked for due runs
+const checkInterval = 30 * time.Second
+
+// Outcomes recorded in BenchmarkSchedule.LastStatus
+const (
+	StatusBenchmarkQueued = "benchmark_queued"
+	StatusNoNewRun        = "no_new_run"
+	StatusFailed          = "failed"
+)
+
+// ErrInvalidSchedule is returned for schedule requests that fail validation
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Runner queues benchmarks for scheduled personas
+type Runner interface {
+	// BenchmarkLatestRun returns nil when the persona has no run left to benchmark
+	BenchmarkLatestRun(personaName string) (*models.BenchmarkResponse, error)
+}
+
+// Scheduler runs persisted benchmark schedules in-process
+type Scheduler struct {
+	runner Runner
+	mu     sync.Mutex // Serialises updates of stored schedules
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// New creates a scheduler that queues benchmarks through runner
+func New(runner Runner) *Scheduler {
+	return &Scheduler{runner: runner}
+}
+
+// Start checks for schedules missed while the service was down, then checks for due schedules until Stop is called
+func (s *Scheduler) Start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		// Any schedule whose next run is already in the past was missed, so the first check catches it up
+		s.runDue(time.Now())
+		for {
+			select {
+			case <-s.stop:
+				return
+			case now := <-ticker.C:
+				s.runDue(now)
+			}
+		}
+	}()
+	log.Printf("Benchmark scheduler started")
+}
+
+// Stop stops the scheduler and waits for the current check to finish
+func (s *Scheduler) Stop() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+}
+
+// runDue runs every enabled schedule whose next run is at or before now.
+// Several missed activations of one schedule collapse into a single run.
+func (s *Scheduler) runDue(now time.Time) {
+	schedules, err := storage.ListSchedules()
+	if err != nil {
+		log.Printf("Error listing benchmark schedules: %v", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		if !schedule.Enabled || schedule.NextRunAt.IsZero() || schedule.NextRunAt.After(now) {
+			continue
+		}
+		if now.Sub(schedule.NextRunAt) > checkInterval {
+			log.Printf("Catching up missed run of schedule %s for persona %s, due at %s", schedule.ID, schedule.PersonaName, schedule.NextRunAt.Format(time.RFC3339))
+		}
+		s.run(schedule.ID, now)
+	}
+}
+
+// run triggers a schedule and records the outcome
+func (s *Scheduler) run(scheduleID string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Reload in case the schedule changed since it was listed
+	schedule, err := storage.GetSchedule(scheduleID)
+	if err != nil {
+		log.Printf("Error loading schedule %s: %v", scheduleID, err)
+		return
+	}
+	if !schedule.Enabled || schedule.NextRunAt.After(now) {
+		return
+	}
+
+	response, err := s.runner.BenchmarkLatestRun(schedule.PersonaName)
+	schedule.LastRunAt = &now
+	schedule.LastError = ""
+	switch {
+	case err != nil:
+		log.Printf("Error running schedule %s for persona %s: %v", schedule.ID, schedule.PersonaName, err)
+		schedule.LastStatus = StatusFailed
+		schedule.LastError = err.Error()
+	case response == nil:
+		schedule.LastStatus = StatusNoNewRun
+	default:
+		schedule.LastStatus = StatusBenchmarkQueued
+		schedule.LastBenchmarkID = response.ID
+	}
+
+	// The next run is computed from now rather than the missed time, so catching up happens once
+	if cron, err := ParseCron(schedule.Cron); err == nil {
+		schedule.NextRunAt = cron.Next(now)
+	} else {
+		log.Printf("Disabling schedule %s with invalid cron expression: %v", schedule.ID, err)
+		schedule.Enabled = false
+	}
+
+	if err := storage.SaveSchedule(*schedule); err != nil {
+		log.Printf("Error saving schedule %s: %v", schedule.ID, err)
+	}
+}
+
+// List returns all schedules
+func (s *Scheduler) List() ([]models.BenchmarkSchedule, error) {
+	return storage.ListSchedules()
+}
+
+// Get returns a schedule by ID
+func (s *Scheduler) Get(scheduleID string) (*models.BenchmarkSchedule, error) {
+	return storage.GetSchedule(scheduleID)
+}
+
+// Create validates and stores a new schedule
+func (s *Scheduler) Create(request models.ScheduleRequest) (*models.BenchmarkSchedule, error) {
+	if strings.TrimSpace(request.PersonaName) == "" {
+		return nil, fmt.Errorf("%w: personaName is required", ErrInvalidSchedule)
+	}
+
+	now := time.Now()
+	schedule := models.BenchmarkSchedule{
+		ID:          uuid.NewString(),
+		PersonaName: request.PersonaName,
+		Cron:        request.Cron,
+		Enabled:     request.Enabled == nil || *request.Enabled,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := setNextRun(&schedule, now); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := storage.SaveSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// Update changes the fields set in request and stores the schedule
+func (s *Scheduler) Update(scheduleID string, request models.ScheduleRequest) (*models.BenchmarkSchedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, err := storage.GetSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.PersonaName != "" {
+		schedule.PersonaName = request.PersonaName
+	}
+	if request.Cron != "" {
+		schedule.Cron = request.Cron
+	}
+	if request.Enabled != nil {
+		schedule.Enabled = *request.Enabled
+	}
+
+	now := time.Now()
+	schedule.UpdatedAt = now
+	if err := setNextRun(schedule, now); err != nil {
+		return nil, err
+	}
+
+	if err := storage.SaveSchedule(*schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// Delete removes a schedule
+func (s *Scheduler) Delete(scheduleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return storage.DeleteSchedule(scheduleID)
+}
+
+// setNextRun validates the schedule's cron expression and computes its next run after now
+func setNextRun(schedule *models.BenchmarkSchedule, now time.Time) error {
+	cron, err := ParseCron(schedule.Cron)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+	next := cron.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("%w: cron expression %q never runs", ErrInvalidSchedule, schedule.Cron)
+	}
+	schedule.NextRunAt = next
+	return nil
+}
@@ -1,18 +1,84 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// MinDriftBaselineRuns is the fewest baseline runs a meaningful drift test needs
+const MinDriftBaselineRuns = 5
+
 type Specification struct {
 	RunDataTTLHours    int      `mapstructure:"RUN_DATA_TTL_HOURS"`
 	CORSAllowedOrigins []string `mapstructure:"CORS_ALLOWED_ORIGINS"`
 	LlmURL             string   `mapstructure:"LLM_URL"`
 	LlmAPIKey          string   `mapstructure:"LLM_API_KEY"`
 	LlmModel           string   `mapstructure:"LLM_MODEL"`
+	LlmJudges          string   `mapstructure:"LLM_JUDGES"`        // JSON array of JudgeConfig; empty means a single judge from LLM_*
+	JudgeAggregation   string   `mapstructure:"JUDGE_AGGREGATION"` // majority or mean
+	JudgeSamples       int      `mapstructure:"JUDGE_SAMPLES"`     // Self-consistency samples per judge; 1 disables sampling
+	JudgeSampleTemp    float64  `mapstructure:"JUDGE_SAMPLE_TEMPERATURE"`
+	JudgeMinConfidence float64  `mapstructure:"JUDGE_CONFIDENCE_THRESHOLD"` // Verdicts below this confidence are flagged for review
+	EmbeddingModel     string   `mapstructure:"EMBEDDING_MODEL"`            // Served from LLM_URL; empty disables semantic similarity
+	EntryIDPatterns    []string `mapstructure:"ENTRY_ID_PATTERNS"`          // Regexes with one capture group, used when structured IDs don't match
+	ModelPrices        string   `mapstructure:"MODEL_PRICES"`               // JSON object of model name to ModelPrice
+	DashboardURL       string   `mapstructure:"DASHBOARD_URL"`              // Base URL for links in notifications
+
+	// Default benchmark budget; zero means no limit. Requests may override each limit.
+	BenchmarkMaxItems    int           `mapstructure:"BENCHMARK_MAX_ITEMS"`
+	BenchmarkMaxTokens   int64         `mapstructure:"BENCHMARK_MAX_TOKENS"`
+	BenchmarkMaxCost     float64       `mapstructure:"BENCHMARK_MAX_COST"` // Estimated USD, requires MODEL_PRICES
+	BenchmarkMaxDuration time.Duration `mapstructure:"BENCHMARK_MAX_DURATION"`
+
+	// Automatic benchmarking of submitted runs
+	AutoBenchmarkPolicy   string   `mapstructure:"AUTO_BENCHMARK_POLICY"`   // off, all, personas or every_n
+	AutoBenchmarkPersonas []string `mapstructure:"AUTO_BENCHMARK_PERSONAS"` // Personas to benchmark under the personas policy
+	AutoBenchmarkEveryN   int      `mapstructure:"AUTO_BENCHMARK_EVERY_N"`  // Benchmark every Nth run of each persona under the every_n policy
+
+	// Regression alert rules, checked after every benchmark; zero disables a rule
+	AlertMinQualityScore      float64 `mapstructure:"ALERT_MIN_QUALITY_SCORE"`
+	AlertMaxQualityDrop       float64 `mapstructure:"ALERT_MAX_QUALITY_DROP"`       // Points below the trailing average
+	AlertTrailingWindow       int     `mapstructure:"ALERT_TRAILING_WINDOW"`        // Previous benchmarks in the trailing average
+	AlertMinRelevanceAccuracy float64 `mapstructure:"ALERT_MIN_RELEVANCE_ACCURACY"` // Percentage of items judged correctly flagged
+	AlertMaxMissingItems      int     `mapstructure:"ALERT_MAX_MISSING_ITEMS"`      // Items dropped from the output or without source
+
+	// Drift detection on run characteristics, checked whenever a run is submitted
+	DriftBaselineRuns int `mapstructure:"DRIFT_BASELINE_RUNS"` // Earlier runs that form the baseline
+	DriftRecentRuns   int `mapstructure:"DRIFT_RECENT_RUNS"`   // Latest runs tested against the baseline
+
+	// Email digests; no recipients or an empty schedule disables sending
+	SMTPHost         string        `mapstructure:"SMTP_HOST"`
+	SMTPPort         int           `mapstructure:"SMTP_PORT"`
+	SMTPUsername     string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword     string        `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom         string        `mapstructure:"SMTP_FROM"`
+	DigestRecipients []string      `mapstructure:"DIGEST_RECIPIENTS"`
+	DigestSchedule   string        `mapstructure:"DIGEST_SCHEDULE"` // Cron expression in server local time
+	DigestPeriod     time.Duration `mapstructure:"DIGEST_PERIOD"`   // How far back each digest looks
+
+	// Judges is the parsed judge panel, populated by GetConfig
+	Judges []JudgeConfig `mapstructure:"-"`
+	// Prices is the parsed price table, populated by GetConfig
+	Prices map[string]ModelPrice `mapstructure:"-"`
+}
+
+// JudgeConfig describes a single LLM judge on the evaluation panel
+type JudgeConfig struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	APIKey string `json:"apiKey"`
+	Model  string `json:"model"`
+}
+
+// ModelPrice is the cost of a model in USD per million tokens
+type ModelPrice struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
 }
 
 // Validate checks if the specification is valid
@@ -20,6 +86,122 @@ func (s *Specification) Validate() error {
 	if s.RunDataTTLHours <= 0 {
 		return fmt.Errorf("RunDataTTLHours must be positive")
 	}
+	if s.JudgeAggregation != "majority" && s.JudgeAggregation != "mean" {
+		return fmt.Errorf("JudgeAggregation must be one of majority, mean")
+	}
+	if s.JudgeSamples < 1 {
+		return fmt.Errorf("JudgeSamples must be at least 1")
+	}
+	if s.JudgeSamples > 1 && (s.JudgeSampleTemp <= 0 || s.JudgeSampleTemp > 2) {
+		return fmt.Errorf("JudgeSampleTemp must be greater than 0 and at most 2 when sampling")
+	}
+	if s.JudgeMinConfidence < 0 || s.JudgeMinConfidence > 1 {
+		return fmt.Errorf("JudgeMinConfidence must be between 0 and 1")
+	}
+	if s.BenchmarkMaxItems < 0 || s.BenchmarkMaxTokens < 0 || s.BenchmarkMaxCost < 0 || s.BenchmarkMaxDuration < 0 {
+		return fmt.Errorf("benchmark budget limits must not be negative")
+	}
+	switch s.AutoBenchmarkPolicy {
+	case "off", "all", "every_n":
+	case "personas":
+		if len(s.AutoBenchmarkPersonas) == 0 {
+			return fmt.Errorf("AutoBenchmarkPersonas must list at least one persona for the personas policy")
+		}
+	default:
+		return fmt.Errorf("AutoBenchmarkPolicy must be one of off, all, personas, every_n")
+	}
+	if s.AutoBenchmarkEveryN < 1 {
+		return fmt.Errorf("AutoBenchmarkEveryN must be at least 1")
+	}
+	if s.AlertMinQualityScore < 0 || s.AlertMinQualityScore > 100 || s.AlertMinRelevanceAccuracy < 0 || s.AlertMinRelevanceAccuracy > 100 {
+		return fmt.Errorf("AlertMinQualityScore and AlertMinRelevanceAccuracy must be between 0 and 100")
+	}
+	if s.AlertMaxQualityDrop < 0 || s.AlertMaxMissingItems < 0 {
+		return fmt.Errorf("AlertMaxQualityDrop and AlertMaxMissingItems must not be negative")
+	}
+	if s.AlertTrailingWindow < 1 {
+		return fmt.Errorf("AlertTrailingWindow must be at least 1")
+	}
+	if s.DriftBaselineRuns < MinDriftBaselineRuns || s.DriftRecentRuns < 1 {
+		return fmt.Errorf("DriftBaselineRuns must be at least %d and DriftRecentRuns at least 1", MinDriftBaselineRuns)
+	}
+	if s.DigestPeriod <= 0 {
+		return fmt.Errorf("DigestPeriod must be positive")
+	}
+	if len(s.DigestRecipients) > 0 && s.DigestSchedule != "" && (s.SMTPHost == "" || s.SMTPFrom == "") {
+		return fmt.Errorf("SMTPHost and SMTPFrom are required to send digests")
+	}
+	for model, price := range s.Prices {
+		if price.Input < 0 || price.Output < 0 {
+			return fmt.Errorf("ModelPrices for %s must not be negative", model)
+		}
+	}
+	for _, pattern := range s.EntryIDPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("EntryIDPatterns contains an invalid pattern %q: %w", pattern, err)
+		}
+		if re.NumSubexp() < 1 {
+			return fmt.Errorf("EntryIDPatterns pattern %q must have a capture group for the ID", pattern)
+		}
+	}
+	return nil
+}
+
+// parseJudges builds the judge panel from LLM_JUDGES, falling back to the single
+// judge described by LLM_URL, LLM_API_KEY and LLM_MODEL
+func (s *Specification) parseJudges() error {
+	if strings.TrimSpace(s.LlmJudges) == "" {
+		s.Judges = []JudgeConfig{{
+			Name:   s.LlmModel,
+			URL:    s.LlmURL,
+			APIKey: s.LlmAPIKey,
+			Model:  s.LlmModel,
+		}}
+		return nil
+	}
+
+	var judges []JudgeConfig
+	if err := json.Unmarshal([]byte(s.LlmJudges), &judges); err != nil {
+		return fmt.Errorf("could not parse LLM_JUDGES: %w", err)
+	}
+	if len(judges) == 0 {
+		return fmt.Errorf("LLM_JUDGES must contain at least one judge")
+	}
+
+	seen := make(map[string]bool)
+	for i := range judges {
+		// Unset fields inherit the default judge settings
+		if judges[i].URL == "" {
+			judges[i].URL = s.LlmURL
+		}
+		if judges[i].APIKey == "" {
+			judges[i].APIKey = s.LlmAPIKey
+		}
+		if judges[i].Model == "" {
+			return fmt.Errorf("judge %d in LLM_JUDGES has no model", i)
+		}
+		if judges[i].Name == "" {
+			judges[i].Name = judges[i].Model
+		}
+		if seen[judges[i].Name] {
+			return fmt.Errorf("duplicate judge name %q in LLM_JUDGES", judges[i].Name)
+		}
+		seen[judges[i].Name] = true
+	}
+	s.Judges = judges
+	return nil
+}
+
+// parsePrices builds the price table from MODEL_PRICES, e.g. {"gpt-4o": {"input": 2.5, "output": 10}}
+func (s *Specification) parsePrices() error {
+	s.Prices = make(map[string]ModelPrice)
+	if strings.TrimSpace(s.ModelPrices) == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(s.ModelPrices), &s.Prices); err != nil {
+		return fmt.Errorf("could not parse MODEL_PRICES: %w", err)
+	}
 	return nil
 }
 
@@ -33,6 +215,37 @@ func GetConfig() (*Specification, error) {
 	v.SetDefault("LLM_URL", "")
 	v.SetDefault("LLM_API_KEY", "")
 	v.SetDefault("LLM_MODEL", "gpt-4")
+	v.SetDefault("LLM_JUDGES", "")
+	v.SetDefault("JUDGE_AGGREGATION", "majority")
+	v.SetDefault("JUDGE_SAMPLES", 1)
+	v.SetDefault("JUDGE_SAMPLE_TEMPERATURE", 0.7)
+	v.SetDefault("JUDGE_CONFIDENCE_THRESHOLD", 0.6)
+	v.SetDefault("EMBEDDING_MODEL", "")
+	v.SetDefault("ENTRY_ID_PATTERNS", []string{`^ID:\s*(\S+)`})
+	v.SetDefault("MODEL_PRICES", "")
+	v.SetDefault("DASHBOARD_URL", "http://localhost:3000")
+	v.SetDefault("BENCHMARK_MAX_ITEMS", 0)
+	v.SetDefault("BENCHMARK_MAX_TOKENS", 0)
+	v.SetDefault("BENCHMARK_MAX_COST", 0.0)
+	v.SetDefault("BENCHMARK_MAX_DURATION", "0s")
+	v.SetDefault("AUTO_BENCHMARK_POLICY", "off")
+	v.SetDefault("AUTO_BENCHMARK_PERSONAS", []string{})
+	v.SetDefault("AUTO_BENCHMARK_EVERY_N", 1)
+	v.SetDefault("ALERT_MIN_QUALITY_SCORE", 0.0)
+	v.SetDefault("ALERT_MAX_QUALITY_DROP", 10.0)
+	v.SetDefault("ALERT_TRAILING_WINDOW", 5)
+	v.SetDefault("ALERT_MIN_RELEVANCE_ACCURACY", 0.0)
+	v.SetDefault("ALERT_MAX_MISSING_ITEMS", 0)
+	v.SetDefault("DRIFT_BASELINE_RUNS", 20)
+	v.SetDefault("DRIFT_RECENT_RUNS", 5)
+	v.SetDefault("SMTP_HOST", "")
+	v.SetDefault("SMTP_PORT", 587)
+	v.SetDefault("SMTP_USERNAME", "")
+	v.SetDefault("SMTP_PASSWORD", "")
+	v.SetDefault("SMTP_FROM", "")
+	v.SetDefault("DIGEST_RECIPIENTS", []string{})
+	v.SetDefault("DIGEST_SCHEDULE", "0 8 * * 1") // Mondays at 08:00
+	v.SetDefault("DIGEST_PERIOD", "168h")
 
 	// Configure Viper to read from .env file
 	v.SetConfigName(".env") // Name of config file (without extension)
@@ -56,6 +269,13 @@ func GetConfig() (*Specification, error) {
 		return nil, fmt.Errorf("could not unmarshal specification: %w", err)
 	}
 
+	if err := s.parseJudges(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if err := s.parsePrices(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	if err := s.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
@@ -19,6 +19,15 @@ const (
 	dbPathPrefix   = "badger"
 	runDataDir     = "rundata"
 	benchmarkDir   = "benchmarks"
+	pairwiseDir    = "pairwise"
+	judgeCacheDir  = "judgecache"
+	scheduleDir    = "schedules"
+	alertDir       = "alerts"
+	webhookDir     = "webhooks"
+	deliveryDir    = "webhookdeliveries"
+	goldenDir      = "golden"
+	annotationDir  = "annotations"
+	personaRunDir  = "personaruns"
 )
 
 // InitDB initializes the BadgerDB database.
@@ -311,6 +320,44 @@ func GetBenchmarkResults(runID string) (*models.BenchmarkResults, error) {
 	return results, nil
 }
 
+// ListBenchmarkResults retrieves all stored benchmark results.
+// Like ListRunMetadata, this iterates the whole prefix; consider an index if the number of benchmarks grows large.
+func ListBenchmarkResults() ([]models.BenchmarkResults, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	var all []models.BenchmarkResults
+	keyPrefix := []byte(fmt.Sprintf("%s/", benchmarkDir))
+
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(keyPrefix); it.ValidForPrefix(keyPrefix); it.Next() {
+			item := it.Item()
+			err := item.Value(func(val []byte) error {
+				var benchmarkResults models.BenchmarkResults
+				if err := json.Unmarshal(val, &benchmarkResults); err != nil {
+					log.Printf("error unmarshalling benchmark results for key %s: %v", string(item.Key()), err)
+					return nil // Skip this item
+				}
+				all = append(all, benchmarkResults)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("error processing benchmark item value: %w", err)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list benchmark results from BadgerDB: %w", err)
+	}
+	return all, nil
+}
+
 // GetBenchmarkResultsByBenchmarkID retrieves benchmark results by benchmark ID
 func GetBenchmarkResultsByBenchmarkID(benchmarkID string) (*models.BenchmarkResults, error) {
 	if db == nil {
@@ -345,3 +392,435 @@ func GetBenchmarkResultsByBenchmarkID(benchmarkID string) (*models.BenchmarkResu
 	}
 	return &results, nil
 }
+
+// putJSON marshals value and stores it under key, applying the run data TTL if requested
+func putJSON(key []byte, value interface{}, withTTL bool) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s to JSON: %w", string(key), err)
+	}
+
+	return db.Update(func(txn *badger.Txn) error {
+		entry := badger.NewEntry(key, jsonData)
+		if withTTL && defaultRunDataTTL > 0 {
+			entry = entry.WithTTL(defaultRunDataTTL)
+		}
+		return txn.SetEntry(entry)
+	})
+}
+
+// getJSON loads the value stored under key into out.
+// The returned error wraps badger.ErrKeyNotFound and mentions "not found" when the key is missing.
+func getJSON(key []byte, out interface{}) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	return db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				return fmt.Errorf("%s not found: %w", string(key), err)
+			}
+			return fmt.Errorf("failed to get %s from BadgerDB: %w", string(key), err)
+		}
+
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return fmt.Errorf("failed to copy value for %s: %w", string(key), err)
+		}
+
+		if err := json.Unmarshal(val, out); err != nil {
+			return fmt.Errorf("failed to unmarshal %s from JSON: %w", string(key), err)
+		}
+		return nil
+	})
+}
+
+// listJSON calls visit with the value of every key under prefix
+func listJSON(prefix []byte, visit func(val []byte) error) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	return db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			if err := item.Value(visit); err != nil {
+				return fmt.Errorf("error processing %s: %w", string(item.Key()), err)
+			}
+		}
+		return nil
+	})
+}
+
+// deleteKey removes key. The returned error mentions "not found" when the key is missing.
+func deleteKey(key []byte) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	return db.Update(func(txn *badger.Txn) error {
+		if _, err := txn.Get(key); err != nil {
+			if err == badger.ErrKeyNotFound {
+				return fmt.Errorf("%s not found: %w", string(key), err)
+			}
+			return fmt.Errorf("failed to get %s from BadgerDB: %w", string(key), err)
+		}
+		return txn.Delete(key)
+	})
+}
+
+// SavePairwiseResults saves pairwise comparison results to BadgerDB
+func SavePairwiseResults(comparisonID string, results models.PairwiseResults) error {
+	key := []byte(fmt.Sprintf("%s/%s", pairwiseDir, comparisonID))
+	if err := putJSON(key, results, true); err != nil {
+		return fmt.Errorf("failed to save pairwise results (ID: %s) to BadgerDB: %w", comparisonID, err)
+	}
+	log.Printf("Successfully saved pairwise results with ID: %s", comparisonID)
+	return nil
+}
+
+// GetPairwiseResults retrieves pairwise comparison results by comparison ID
+func GetPairwiseResults(comparisonID string) (*models.PairwiseResults, error) {
+	key := []byte(fmt.Sprintf("%s/%s", pairwiseDir, comparisonID))
+	var results models.PairwiseResults
+	if err := getJSON(key, &results); err != nil {
+		return nil, fmt.Errorf("pairwise results with ID '%s': %w", comparisonID, err)
+	}
+	return &results, nil
+}
+
+// SaveJudgeResponse caches a judge response under its content hash.
+// Entries expire with the run data TTL so the cache does not grow without bound.
+func SaveJudgeResponse(hash, response string) error {
+	key := []byte(fmt.Sprintf("%s/%s", judgeCacheDir, hash))
+	if err := putJSON(key, response, true); err != nil {
+		return fmt.Errorf("failed to save judge response %s to BadgerDB: %w", hash, err)
+	}
+	return nil
+}
+
+// GetJudgeResponse retrieves a cached judge response by its content hash
+func GetJudgeResponse(hash string) (string, error) {
+	key := []byte(fmt.Sprintf("%s/%s", judgeCacheDir, hash))
+	var response string
+	if err := getJSON(key, &response); err != nil {
+		return "", fmt.Errorf("judge response %s: %w", hash, err)
+	}
+	return response, nil
+}
+
+// SaveSchedule saves a benchmark schedule. Schedules are configuration, so they never expire.
+func SaveSchedule(schedule models.BenchmarkSchedule) error {
+	key := []byte(fmt.Sprintf("%s/%s", scheduleDir, schedule.ID))
+	if err := putJSON(key, schedule, false); err != nil {
+		return fmt.Errorf("failed to save schedule (ID: %s) to BadgerDB: %w", schedule.ID, err)
+	}
+	return nil
+}
+
+// GetSchedule retrieves a benchmark schedule by ID
+func GetSchedule(scheduleID string) (*models.BenchmarkSchedule, error) {
+	key := []byte(fmt.Sprintf("%s/%s", scheduleDir, scheduleID))
+	var schedule models.BenchmarkSchedule
+	if err := getJSON(key, &schedule); err != nil {
+		return nil, fmt.Errorf("schedule with ID '%s': %w", scheduleID, err)
+	}
+	return &schedule, nil
+}
+
+// ListSchedules retrieves all benchmark schedules
+func ListSchedules() ([]models.BenchmarkSchedule, error) {
+	var schedules []models.BenchmarkSchedule
+	err := listJSON([]byte(scheduleDir+"/"), func(val []byte) error {
+		var schedule models.BenchmarkSchedule
+		if err := json.Unmarshal(val, &schedule); err != nil {
+			log.Printf("error unmarshalling schedule: %v", err)
+			return nil // Skip this item
+		}
+		schedules = append(schedules, schedule)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules from BadgerDB: %w", err)
+	}
+	return schedules, nil
+}
+
+// DeleteSchedule deletes a benchmark schedule by ID
+func DeleteSchedule(scheduleID string) error {
+	key := []byte(fmt.Sprintf("%s/%s", scheduleDir, scheduleID))
+	if err := deleteKey(key); err != nil {
+		return fmt.Errorf("schedule with ID '%s': %w", scheduleID, err)
+	}
+	return nil
+}
+
+// SaveAlert saves an alert. Alerts are kept beyond the run data TTL so regressions remain traceable.
+func SaveAlert(alert models.Alert) error {
+	key := []byte(fmt.Sprintf("%s/%s", alertDir, alert.ID))
+	if err := putJSON(key, alert, false); err != nil {
+		return fmt.Errorf("failed to save alert (ID: %s) to BadgerDB: %w", alert.ID, err)
+	}
+	return nil
+}
+
+// GetAlert retrieves an alert by ID
+func GetAlert(alertID string) (*models.Alert, error) {
+	key := []byte(fmt.Sprintf("%s/%s", alertDir, alertID))
+	var alert models.Alert
+	if err := getJSON(key, &alert); err != nil {
+		return nil, fmt.Errorf("alert with ID '%s': %w", alertID, err)
+	}
+	return &alert, nil
+}
+
+// ListAlerts retrieves all alerts
+func ListAlerts() ([]models.Alert, error) {
+	var alerts []models.Alert
+	err := listJSON([]byte(alertDir+"/"), func(val []byte) error {
+		var alert models.Alert
+		if err := json.Unmarshal(val, &alert); err != nil {
+			log.Printf("error unmarshalling alert: %v", err)
+			return nil // Skip this item
+		}
+		alerts = append(alerts, alert)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts from BadgerDB: %w", err)
+	}
+	return alerts, nil
+}
+
+// SaveWebhook saves a webhook. Webhooks are configuration, so they never expire.
+func SaveWebhook(webhook models.Webhook) error {
+	key := []byte(fmt.Sprintf("%s/%s", webhookDir, webhook.ID))
+	if err := putJSON(key, webhook, false); err != nil {
+		return fmt.Errorf("failed to save webhook (ID: %s) to BadgerDB: %w", webhook.ID, err)
+	}
+	return nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func GetWebhook(webhookID string) (*models.Webhook, error) {
+	key := []byte(fmt.Sprintf("%s/%s", webhookDir, webhookID))
+	var webhook models.Webhook
+	if err := getJSON(key, &webhook); err != nil {
+		return nil, fmt.Errorf("webhook with ID '%s': %w", webhookID, err)
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks retrieves all webhooks
+func ListWebhooks() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := listJSON([]byte(webhookDir+"/"), func(val []byte) error {
+		var webhook models.Webhook
+		if err := json.Unmarshal(val, &webhook); err != nil {
+			log.Printf("error unmarshalling webhook: %v", err)
+			return nil // Skip this item
+		}
+		webhooks = append(webhooks, webhook)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks from BadgerDB: %w", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook by ID. Its delivery log expires with the run data TTL.
+func DeleteWebhook(webhookID string) error {
+	key := []byte(fmt.Sprintf("%s/%s", webhookDir, webhookID))
+	if err := deleteKey(key); err != nil {
+		return fmt.Errorf("webhook with ID '%s': %w", webhookID, err)
+	}
+	return nil
+}
+
+// SaveWebhookDelivery saves a delivery record under its webhook. Records expire with the run data TTL.
+func SaveWebhookDelivery(delivery models.WebhookDelivery) error {
+	key := []byte(fmt.Sprintf("%s/%s/%s", deliveryDir, delivery.WebhookID, delivery.ID))
+	if err := putJSON(key, delivery, true); err != nil {
+		return fmt.Errorf("failed to save webhook delivery (ID: %s) to BadgerDB: %w", delivery.ID, err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries retrieves the delivery records of a webhook
+func ListWebhookDeliveries(webhookID string) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := listJSON([]byte(fmt.Sprintf("%s/%s/", deliveryDir, webhookID)), func(val []byte) error {
+		var delivery models.WebhookDelivery
+		if err := json.Unmarshal(val, &delivery); err != nil {
+			log.Printf("error unmarshalling webhook delivery: %v", err)
+			return nil // Skip this item
+		}
+		deliveries = append(deliveries, delivery)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries from BadgerDB: %w", err)
+	}
+	return deliveries, nil
+}
+
+// SaveGoldenDataset saves a golden dataset. Datasets are reference data, so they never expire.
+func SaveGoldenDataset(dataset models.GoldenDataset) error {
+	key := []byte(fmt.Sprintf("%s/%s", goldenDir, dataset.ID))
+	if err := putJSON(key, dataset, false); err != nil {
+		return fmt.Errorf("failed to save golden dataset (ID: %s) to BadgerDB: %w", dataset.ID, err)
+	}
+	return nil
+}
+
+// GetGoldenDataset retrieves a golden dataset by ID
+func GetGoldenDataset(datasetID string) (*models.GoldenDataset, error) {
+	key := []byte(fmt.Sprintf("%s/%s", goldenDir, datasetID))
+	var dataset models.GoldenDataset
+	if err := getJSON(key, &dataset); err != nil {
+		return nil, fmt.Errorf("golden dataset with ID '%s': %w", datasetID, err)
+	}
+	return &dataset, nil
+}
+
+// ListGoldenDatasets retrieves all golden datasets
+func ListGoldenDatasets() ([]models.GoldenDataset, error) {
+	var datasets []models.GoldenDataset
+	err := listJSON([]byte(goldenDir+"/"), func(val []byte) error {
+		var dataset models.GoldenDataset
+		if err := json.Unmarshal(val, &dataset); err != nil {
+			log.Printf("error unmarshalling golden dataset: %v", err)
+			return nil // Skip this item
+		}
+		datasets = append(datasets, dataset)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list golden datasets from BadgerDB: %w", err)
+	}
+	return datasets, nil
+}
+
+// DeleteGoldenDataset deletes a golden dataset by ID
+func DeleteGoldenDataset(datasetID string) error {
+	key := []byte(fmt.Sprintf("%s/%s", goldenDir, datasetID))
+	if err := deleteKey(key); err != nil {
+		return fmt.Errorf("golden dataset with ID '%s': %w", datasetID, err)
+	}
+	return nil
+}
+
+// SaveAnnotation saves a human annotation under its run. Annotations are kept after the run expires.
+func SaveAnnotation(annotation models.Annotation) error {
+	key := []byte(fmt.Sprintf("%s/%s/%s", annotationDir, annotation.RunID, annotation.ID))
+	if err := putJSON(key, annotation, false); err != nil {
+		return fmt.Errorf("failed to save annotation (ID: %s) to BadgerDB: %w", annotation.ID, err)
+	}
+	return nil
+}
+
+// GetAnnotation retrieves an annotation of a run by ID
+func GetAnnotation(runID, annotationID string) (*models.Annotation, error) {
+	key := []byte(fmt.Sprintf("%s/%s/%s", annotationDir, runID, annotationID))
+	var annotation models.Annotation
+	if err := getJSON(key, &annotation); err != nil {
+		return nil, fmt.Errorf("annotation with ID '%s': %w", annotationID, err)
+	}
+	return &annotation, nil
+}
+
+// ListAnnotations retrieves the annotations of a run, or of every run if runID is empty
+func ListAnnotations(runID string) ([]models.Annotation, error) {
+	prefix := annotationDir + "/"
+	if runID != "" {
+		prefix += runID + "/"
+	}
+
+	var annotations []models.Annotation
+	err := listJSON([]byte(prefix), func(val []byte) error {
+		var annotation models.Annotation
+		if err := json.Unmarshal(val, &annotation); err != nil {
+			log.Printf("error unmarshalling annotation: %v", err)
+			return nil // Skip this item
+		}
+		annotations = append(annotations, annotation)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list annotations from BadgerDB: %w", err)
+	}
+	return annotations, nil
+}
+
+// DeleteAnnotation deletes an annotation of a run by ID
+func DeleteAnnotation(runID, annotationID string) error {
+	key := []byte(fmt.Sprintf("%s/%s/%s", annotationDir, runID, annotationID))
+	if err := deleteKey(key); err != nil {
+		return fmt.Errorf("annotation with ID '%s': %w", annotationID, err)
+	}
+	return nil
+}
+
+// personaRunCount is the number of distinct runs ever submitted for a persona
+type personaRunCount struct {
+	Count int `json:"count"`
+}
+
+// CountPersonaRun records a run of a persona and returns how many distinct runs the persona has had,
+// including this one. Counts and the run IDs behind them never expire, so the count keeps growing
+// after old run data has expired. A run ID that was already counted returns the current count and false.
+func CountPersonaRun(personaName, runID string) (int, bool, error) {
+	if db == nil {
+		return 0, false, fmt.Errorf("database not initialized")
+	}
+
+	countKey := []byte(fmt.Sprintf("%s/%s/count", personaRunDir, personaName))
+	seenKey := []byte(fmt.Sprintf("%s/%s/runs/%s", personaRunDir, personaName, runID))
+
+	var count personaRunCount
+	counted := false
+	err := db.Update(func(txn *badger.Txn) error {
+		count, counted = personaRunCount{}, false
+
+		item, err := txn.Get(countKey)
+		switch {
+		case err == nil:
+			if err := item.Value(func(val []byte) error { return json.Unmarshal(val, &count) }); err != nil {
+				return fmt.Errorf("failed to read run count of persona %s: %w", personaName, err)
+			}
+		case err != badger.ErrKeyNotFound:
+			return fmt.Errorf("failed to get run count of persona %s: %w", personaName, err)
+		}
+
+		if _, err := txn.Get(seenKey); err == nil {
+			return nil // Resubmitted run
+		} else if err != badger.ErrKeyNotFound {
+			return fmt.Errorf("failed to check run %s of persona %s: %w", runID, personaName, err)
+		}
+
+		count.Count++
+		counted = true
+		jsonData, err := json.Marshal(count)
+		if err != nil {
+			return fmt.Errorf("failed to marshal run count to JSON: %w", err)
+		}
+		if err := txn.Set(seenKey, nil); err != nil {
+			return err
+		}
+		return txn.Set(countKey, jsonData)
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to count run %s of persona %s in BadgerDB: %w", runID, personaName, err)
+	}
+	return count.Count, counted, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bakkerme/ai-news-auditability-service/internal/models"
+)
+
+func TestCountPersonaRunAcrossExpiry(t *testing.T) {
+	if err := InitDB(t.TempDir(), 0); err != nil {
+		t.Fatalf("InitDB() error = %v", err)
+	}
+	t.Cleanup(func() {
+		CloseDB()
+		db, defaultRunDataTTL = nil, 0
+	})
+	defaultRunDataTTL = time.Second
+
+	submit := func(runID string) (int, bool) {
+		t.Helper()
+		run := models.PersistedRunData{RunID: runID}
+		run.Persona.Name = "persona"
+		run.RunDate = time.Now()
+		if err := SaveRunData(runID, run); err != nil {
+			t.Fatalf("SaveRunData(%s) error = %v", runID, err)
+		}
+		count, counted, err := CountPersonaRun("persona", runID)
+		if err != nil {
+			t.Fatalf("CountPersonaRun(%s) error = %v", runID, err)
+		}
+		return count, counted
+	}
+
+	if count, counted := submit("a"); count != 1 || !counted {
+		t.Errorf("first run = %d, %v, want 1, true", count, counted)
+	}
+	if count, counted := submit("b"); count != 2 || !counted {
+		t.Errorf("second run = %d, %v, want 2, true", count, counted)
+	}
+	if count, counted := submit("b"); count != 2 || counted {
+		t.Errorf("resubmitted run = %d, %v, want 2, false", count, counted)
+	}
+	if count, _, err := CountPersonaRun("other", "a"); err != nil || count != 1 {
+		t.Errorf("other persona = %d, %v, want 1", count, err)
+	}
+
+	// Once the stored runs expire the count keeps growing, and expired run IDs are not counted again
+	time.Sleep(2 * time.Second)
+	runs, err := ListRunMetadata(-1)
+	if err != nil || len(runs) != 0 {
+		t.Fatalf("ListRunMetadata() = %d runs, %v, want the runs to have expired", len(runs), err)
+	}
+	if count, counted := submit("c"); count != 3 || !counted {
+		t.Errorf("run after expiry = %d, %v, want 3, true", count, counted)
+	}
+	if count, counted := submit("a"); count != 3 || counted {
+		t.Errorf("expired run resubmitted = %d, %v, want 3, false", count, counted)
+	}
+}
@@ -6,7 +6,14 @@ import (
 	"os"
 
 	"github.com/bakkerme/ai-news-auditability-service/internal"
+	"github.com/bakkerme/ai-news-auditability-service/internal/alerting"
 	"github.com/bakkerme/ai-news-auditability-service/internal/api"
+	"github.com/bakkerme/ai-news-auditability-service/internal/benchmark"
+	"github.com/bakkerme/ai-news-auditability-service/internal/digest"
+	"github.com/bakkerme/ai-news-auditability-service/internal/drift"
+	"github.com/bakkerme/ai-news-auditability-service/internal/events"
+	"github.com/bakkerme/ai-news-auditability-service/internal/notify"
+	"github.com/bakkerme/ai-news-auditability-service/internal/scheduler"
 	"github.com/bakkerme/ai-news-auditability-service/internal/storage"
 
 	"github.com/labstack/echo/v4"
@@ -46,8 +53,44 @@ func main() {
 		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
 	}))
 
+	// Run, benchmark and alert events are delivered in-process
+	bus := events.NewBus()
+
+	// Initialize benchmark service with the judge panel from spec
+	benchmarkService := benchmark.NewBenchmarkService(spec)
+	benchmarkService.SetEventBus(bus)
+
+	// Check every completed benchmark for quality regressions
+	alertService := alerting.NewService(alerting.RulesFromSpec(spec), bus)
+
+	// Check each persona for drift in its run characteristics as runs arrive
+	driftService := drift.NewService(spec, alertService, bus)
+
+	// Deliver events to the configured webhooks and chat channels
+	webhookService := notify.NewService(bus, spec.DashboardURL)
+
+	// Run benchmark schedules in-process, catching up any missed while the service was down
+	sched := scheduler.New(benchmarkService)
+	sched.Start()
+	defer sched.Stop()
+
+	// Email digests of audit results on the configured schedule
+	digestService := digest.NewService(spec)
+	if err := digestService.Start(); err != nil {
+		log.Fatalf("Failed to start digest emails: %v", err)
+	}
+	defer digestService.Stop()
+
 	// Create API handler instance
-	apiHandler := api.NewAPI(spec)
+	apiHandler := api.NewAPI(spec, api.Services{
+		Benchmarks: benchmarkService,
+		Scheduler:  sched,
+		Alerts:     alertService,
+		Webhooks:   webhookService,
+		Digests:    digestService,
+		Drift:      driftService,
+		Events:     bus,
+	})
 
 	// Routes
 	api.RegisterRoutes(e, apiHandler)